@@ -0,0 +1,140 @@
+package triego
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrKeyOrder = errors.New("triego: keys must be added in sorted order")
+
+// A Builder builds a radix tree from keys
+// added in lexicographic order, as returned
+// by KeysOrdered, in a single pass: since no
+// key added later can fall between the nodes
+// already built, each node is created with
+// its final label and no node is ever split.
+// The resulting radix tree is the same that
+// inserting the keys would produce.
+type Builder[V any] struct {
+	root *Trie[V]
+	opts []Option
+
+	// the last key added and the nodes
+	// along its path which can still
+	// receive children, from the root on
+	key    []rune
+	frames []build_frame[V]
+}
+
+// A node still open along the path of the
+// last key: its label is the part of the key
+// between start and end
+type build_frame[V any] struct {
+	node       *Trie[V]
+	start, end int
+}
+
+// Returns a new Builder for a radix tree
+// configured with the given options
+func NewBuilder[V any](opts ...Option) *Builder[V] {
+	b := &Builder[V]{opts: opts}
+	b.reset()
+
+	return b
+}
+
+func (b *Builder[V]) reset() {
+	b.root = NewTrie[V](b.opts...)
+	b.key = nil
+	b.frames = []build_frame[V]{{b.root, 0, 0}}
+}
+
+// Adds the given key with the given value,
+// storing it verbatim like Insert. Returns
+// ErrKeyOrder, adding nothing, if the key is
+// lower than the last one added, once normalized.
+// Adding the last key again replaces its value.
+func (b *Builder[V]) Add(key string, value V) error {
+	return b.AddWeighted(key, value, 0)
+}
+
+// Adds the given key like Add assigning
+// it the given weight
+func (b *Builder[V]) AddWeighted(key string, value V, weight float64) error {
+	k := b.root.key_runes(key)
+	if len(k) == 0 {
+		return nil
+	}
+
+	switch compare_runes(k, b.key) {
+	case -1:
+		return fmt.Errorf("%w: %q after %q", ErrKeyOrder, string(k), string(b.key))
+	case 0:
+		n := b.frames[len(b.frames)-1].node
+		n.data = []V{value}
+		n.weight = weight
+		return nil
+	}
+
+	// closing the nodes the new key
+	// does not share with the last one
+	common := same_until(k, b.key) + 1
+	for len(b.frames) > 1 && b.frames[len(b.frames)-1].start >= common {
+		b.close()
+	}
+
+	// the new key diverges within the
+	// label of the deepest open node: its
+	// node is closed as a child of a new
+	// node labelled with the common part
+	if top := b.frames[len(b.frames)-1]; common < top.end {
+		n := new(Trie[V])
+		n.gen = b.root.gen
+		n.Children = make([]*Trie[V], 0, 2)
+
+		b.frames[len(b.frames)-1] = build_frame[V]{n, top.start, common}
+		b.frames = append(b.frames, build_frame[V]{top.node, common, top.end})
+		b.close()
+	}
+
+	n := new(Trie[V])
+	n.IsWord = true
+	n.data = []V{value}
+	n.weight = weight
+	n.gen = b.root.gen
+	n.Children = make([]*Trie[V], 0)
+
+	b.key = k
+	b.frames = append(b.frames, build_frame[V]{n, common, len(k)})
+
+	return nil
+}
+
+// Closes the deepest open node
+// adding it to its parent
+func (b *Builder[V]) close() {
+	f := b.frames[len(b.frames)-1]
+	b.frames = b.frames[:len(b.frames)-1]
+	parent := b.frames[len(b.frames)-1].node
+
+	f.node.chars = make([]rune, f.end-f.start)
+	copy(f.node.chars, b.key[f.start:f.end])
+	update_subtree([]*Trie[V]{f.node})
+
+	parent.Children = append(parent.Children, f.node)
+}
+
+// Returns the radix tree holding all the
+// keys added so far. The Builder starts
+// building a new empty radix tree.
+func (b *Builder[V]) Build() *Trie[V] {
+	for len(b.frames) > 1 {
+		b.close()
+	}
+	update_subtree([]*Trie[V]{b.root})
+
+	t := b.root
+	b.reset()
+
+	return t
+}
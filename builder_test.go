@@ -0,0 +1,96 @@
+package triego
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func Test_Builder(t *testing.T) {
+	words := random_corpus(5000)
+	words = append(words, order_words...)
+	words = append(words, "roman", "romanes", "ro", "r")
+
+	inserted := NewTrie[string]()
+	for i, w := range words {
+		inserted.InsertWeighted(w, w, float64(i%7))
+	}
+
+	b := NewBuilder[string]()
+	for _, k := range inserted.KeysOrdered(Lexicographic) {
+		weight, _ := inserted.Weight(k)
+		if err := b.AddWeighted(k, k, weight); err != nil {
+			t.Fatal(err)
+		}
+	}
+	built := b.Build()
+
+	if diff := compare_tries(inserted, built); diff != "" {
+		t.Errorf("Unexpected difference between built and inserted radix trees: %s", diff)
+	}
+	if key, ok := check_compact(built); !ok {
+		t.Errorf("Unexpected built node '%s'", key)
+	}
+
+	// the builder starts over
+	if empty := b.Build(); empty.Len() != 0 || len(empty.Children) != 0 {
+		t.Errorf("Unexpected radix tree after a second Build: %d words", empty.Len())
+	}
+}
+
+func Test_BuilderKeyOrder(t *testing.T) {
+	b := NewBuilder[int](WithNormalization(FoldCase))
+
+	for i, k := range []string{"arma", "Armatura", "ARMATURA", "rom"} {
+		if err := b.Add(k, i); err != nil {
+			t.Errorf("Unexpected Add('%s') error: %v", k, err)
+		}
+	}
+	if err := b.Add("armento", 4); !errors.Is(err, ErrKeyOrder) {
+		t.Errorf("Unexpected Add('armento') error: %v", err)
+	}
+	if err := b.Add("romane", 5); err != nil {
+		t.Errorf("Unexpected Add('romane') error: %v", err)
+	}
+
+	trie := b.Build()
+	expected := []string{"arma", "armatura", "rom", "romane"}
+	if keys := trie.KeysOrdered(Lexicographic); !slices.Equal(keys, expected) {
+		t.Errorf("Unexpected keys: got %v, expected %v", keys, expected)
+	}
+	// adding a key again replaces its value
+	if v, _ := trie.Get("armatura"); v != 2 {
+		t.Errorf("Unexpected Get('armatura') result: %d", v)
+	}
+	if trie.HasWord("armento") {
+		t.Errorf("Unexpected: out of order key 'armento' has been added")
+	}
+}
+
+func Benchmark_Builder(b *testing.B) {
+	words := random_corpus(100000)
+	slices.Sort(words)
+	words = slices.Compact(words)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		builder := NewBuilder[string]()
+		for _, w := range words {
+			builder.Add(w, w)
+		}
+		builder.Build()
+	}
+}
+
+func Benchmark_Insert(b *testing.B) {
+	words := random_corpus(100000)
+	slices.Sort(words)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie := NewTrie[string]()
+		for _, w := range words {
+			trie.Insert(w, w)
+		}
+	}
+}
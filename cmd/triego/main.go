@@ -0,0 +1,403 @@
+// Command triego builds, queries and inspects
+// radix trees serialized by the triego package.
+//
+// Usage:
+//
+//	triego build [-tokenize] [-v] [-norm flags] [-o file] [wordlist...]
+//	triego query [-norm flags] [-n limit] [-d distance] file has|closest|complete|fuzzy term
+//	triego stats [-norm flags] file
+//	triego dump [-norm flags] [-tsv] file
+//	triego diff [-norm flags] file1 file2
+//
+// Word lists hold a key per line, optionally followed
+// by a tab separated weight and a JSON encoded value.
+// Radix trees are read with the normalization given
+// by -norm, a comma separated list of fold, nfc, nfkc
+// and diacritics, which must match the one they have
+// been built with. build -v reports the loading
+// progress on the standard error.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/typeflow/triego"
+)
+
+const usage = `usage:
+  triego build [-tokenize] [-v] [-norm flags] [-o file] [wordlist...]
+  triego query [-norm flags] [-n limit] [-d distance] file has|closest|complete|fuzzy term
+  triego stats [-norm flags] file
+  triego dump [-norm flags] [-tsv] file
+  triego diff [-norm flags] file1 file2
+`
+
+// Exit codes: like grep and diff a query
+// without results or a diff with differences
+// exit with 1, while errors exit with 2
+const (
+	exit_ok       = 0
+	exit_no_match = 1
+	exit_error    = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// Encodes values in JSON so that any
+// value read from a word list can be
+// stored and printed back
+type json_codec struct{}
+
+func (json_codec) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (json_codec) Unmarshal(data []byte) (value any, err error) {
+	err = json.Unmarshal(data, &value)
+	return
+}
+
+// The state of a command being run
+type command struct {
+	flags  *flag.FlagSet
+	norm   string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exit_error
+	}
+
+	commands := map[string]func(*command, []string) (int, error){
+		"build": (*command).build,
+		"query": (*command).query,
+		"stats": (*command).stats,
+		"dump":  (*command).dump,
+		"diff":  (*command).diff,
+	}
+	fn, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "triego: unknown command %q\n%s", args[0], usage)
+		return exit_error
+	}
+
+	c := &command{
+		flags:  flag.NewFlagSet("triego "+args[0], flag.ContinueOnError),
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+	c.flags.SetOutput(stderr)
+	c.flags.StringVar(&c.norm, "norm", "", "normalization: a comma separated list of fold, nfc, nfkc and diacritics")
+
+	code, err := fn(c, args[1:])
+	if err != nil {
+		fmt.Fprintln(stderr, "triego:", err)
+		return exit_error
+	}
+
+	return code
+}
+
+// Parses the flags of the command checking
+// the number of positional arguments
+func (c *command) parse(args []string, min_args, max_args int) ([]string, error) {
+	if err := c.flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if n := c.flags.NArg(); n < min_args || (max_args >= 0 && n > max_args) {
+		return nil, fmt.Errorf("wrong number of arguments\n%s", usage)
+	}
+
+	return c.flags.Args(), nil
+}
+
+func (c *command) options() ([]triego.Option, error) {
+	var n triego.Normalization
+	for _, name := range strings.Split(c.norm, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case "fold":
+			n |= triego.FoldCase
+		case "nfc":
+			n |= triego.NFC
+		case "nfkc":
+			n |= triego.NFKC
+		case "diacritics":
+			n |= triego.StripDiacritics
+		default:
+			return nil, fmt.Errorf("unknown normalization %q", name)
+		}
+	}
+
+	return []triego.Option{
+		triego.WithNormalization(n),
+		triego.WithCodec[any](json_codec{}),
+	}, nil
+}
+
+func (c *command) new_trie() (*triego.Trie[any], error) {
+	opts, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+
+	return triego.NewTrie[any](opts...), nil
+}
+
+// Reads the radix tree serialized in
+// the given file, "-" for the standard input
+func (c *command) read(name string) (*triego.Trie[any], error) {
+	trie, err := c.new_trie()
+	if err != nil {
+		return nil, err
+	}
+
+	r := c.stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if _, err := trie.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return trie, nil
+}
+
+func (c *command) build(args []string) (int, error) {
+	var opts triego.LoadOptions
+	output := c.flags.String("o", "-", "output file, - for the standard output")
+	verbose := c.flags.Bool("v", false, "report the progress on the standard error")
+	c.flags.BoolVar(&opts.Tokenize, "tokenize", false, "append each key as a phrase split into words")
+
+	files, err := c.parse(args, 0, -1)
+	if err != nil {
+		return exit_error, err
+	}
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	trie, err := c.new_trie()
+	if err != nil {
+		return exit_error, err
+	}
+
+	for _, name := range files {
+		if *verbose {
+			opts.Progress = func(lines int, bytes int64) {
+				fmt.Fprintf(c.stderr, "%s: %d lines, %d bytes\n", name, lines, bytes)
+			}
+		}
+		if err := c.load(trie, name, opts); err != nil {
+			return exit_error, err
+		}
+	}
+
+	if *output == "-" {
+		if _, err := trie.WriteTo(c.stdout); err != nil {
+			return exit_error, err
+		}
+		return exit_ok, nil
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return exit_error, err
+	}
+	_, err = trie.WriteTo(f)
+	// a failed close can lose
+	// the last bytes written
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return exit_error, err
+	}
+
+	return exit_ok, nil
+}
+
+func (c *command) load(trie *triego.Trie[any], name string, opts triego.LoadOptions) error {
+	r := c.stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := trie.LoadFrom(r, opts); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (c *command) query(args []string) (int, error) {
+	limit := c.flags.Int("n", 10, "maximum number of completions, 0 for all")
+	distance := c.flags.Int("d", 1, "maximum edit distance of fuzzy matches")
+
+	args, err := c.parse(args, 3, 3)
+	if err != nil {
+		return exit_error, err
+	}
+	trie, err := c.read(args[0])
+	if err != nil {
+		return exit_error, err
+	}
+
+	term := args[2]
+	var matches []triego.Match[any]
+
+	switch args[1] {
+	case "has":
+		found := trie.HasWord(term)
+		fmt.Fprintln(c.stdout, found)
+		if !found {
+			return exit_no_match, nil
+		}
+		return exit_ok, nil
+	case "closest":
+		words := trie.ClosestWords(term)
+		for _, w := range words {
+			fmt.Fprintln(c.stdout, format_value(w))
+		}
+		if len(words) == 0 {
+			return exit_no_match, nil
+		}
+		return exit_ok, nil
+	case "complete":
+		matches = trie.Complete(term, *limit)
+		for _, m := range matches {
+			fmt.Fprintf(c.stdout, "%s\t%g\t%s\n", m.Key, m.Weight, format_value(m.Value))
+		}
+	case "fuzzy":
+		matches = trie.FuzzySearch(term, *distance)
+		for _, m := range matches {
+			fmt.Fprintf(c.stdout, "%s\t%d\t%s\n", m.Key, m.Distance, format_value(m.Value))
+		}
+	default:
+		return exit_error, fmt.Errorf("unknown query %q", args[1])
+	}
+
+	if len(matches) == 0 {
+		return exit_no_match, nil
+	}
+	return exit_ok, nil
+}
+
+func (c *command) stats(args []string) (int, error) {
+	args, err := c.parse(args, 1, 1)
+	if err != nil {
+		return exit_error, err
+	}
+	trie, err := c.read(args[0])
+	if err != nil {
+		return exit_error, err
+	}
+
+	fmt.Fprint(c.stdout, trie.Stats())
+	return exit_ok, nil
+}
+
+func (c *command) dump(args []string) (int, error) {
+	tsv := c.flags.Bool("tsv", false, "write the words in the word list format instead")
+
+	args, err := c.parse(args, 1, 1)
+	if err != nil {
+		return exit_error, err
+	}
+	trie, err := c.read(args[0])
+	if err != nil {
+		return exit_error, err
+	}
+
+	if *tsv {
+		return exit_ok, trie.Export(c.stdout)
+	}
+	fmt.Fprint(c.stdout, trie)
+	return exit_ok, nil
+}
+
+// Prints the keys found only in the first
+// radix tree prefixed by '-', the ones found
+// only in the second one prefixed by '+' and
+// the ones whose values or weight changed
+// prefixed by '~', in lexicographic order
+func (c *command) diff(args []string) (int, error) {
+	args, err := c.parse(args, 2, 2)
+	if err != nil {
+		return exit_error, err
+	}
+	a, err := c.read(args[0])
+	if err != nil {
+		return exit_error, err
+	}
+	b, err := c.read(args[1])
+	if err != nil {
+		return exit_error, err
+	}
+
+	type change struct {
+		key  string
+		kind byte
+	}
+	changes := []change{}
+
+	for _, k := range a.Difference(b).KeysOrdered(triego.Lexicographic) {
+		changes = append(changes, change{k, '-'})
+	}
+	for _, k := range b.Difference(a).KeysOrdered(triego.Lexicographic) {
+		changes = append(changes, change{k, '+'})
+	}
+	for _, k := range a.Intersect(b).KeysOrdered(triego.Lexicographic) {
+		wa, _ := a.Weight(k)
+		wb, _ := b.Weight(k)
+		if wa != wb || !reflect.DeepEqual(a.GetAll(k), b.GetAll(k)) {
+			changes = append(changes, change{k, '~'})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(x, y change) int {
+		return strings.Compare(x.key, y.key)
+	})
+	for _, ch := range changes {
+		fmt.Fprintf(c.stdout, "%c %s\n", ch.kind, ch.key)
+	}
+
+	if len(changes) > 0 {
+		return exit_no_match, nil
+	}
+	return exit_ok, nil
+}
+
+func format_value(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const words = `# test word list
+italy	59	{"code":"IT"}
+iceland	0.3
+iran	85
+ireland
+`
+
+type run_test struct {
+	args     []string
+	code     int
+	expected string
+}
+
+func Test_Run(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(list, []byte(words), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first := filepath.Join(dir, "first.bin")
+	second := filepath.Join(dir, "second.bin")
+
+	run_tests := []run_test{
+		{[]string{"build", "-o", first, list}, exit_ok, ""},
+		{[]string{"query", first, "has", "italy"}, exit_ok, "true\n"},
+		{[]string{"query", first, "has", "ital"}, exit_no_match, "false\n"},
+		{[]string{"query", first, "closest", "italy"}, exit_ok, "{\"code\":\"IT\"}\n"},
+		{[]string{"query", "-n", "2", first, "complete", "i"}, exit_ok, "iran\t85\t\"iran\"\nitaly\t59\t{\"code\":\"IT\"}\n"},
+		{[]string{"query", first, "fuzzy", "iren"}, exit_ok, "iran\t1\t\"iran\"\n"},
+		{[]string{"query", first, "complete", "x"}, exit_no_match, ""},
+		{[]string{"dump", first}, exit_ok, "/\n  i\n    celand *\n    r\n      an *\n      eland *\n    taly *\n"},
+		{[]string{"dump", "-tsv", first}, exit_ok, "iceland\t0.3\t\"iceland\"\niran\t85\t\"iran\"\nireland\t0\t\"ireland\"\nitaly\t59\t{\"code\":\"IT\"}\n"},
+		{[]string{"diff", first, first}, exit_ok, ""},
+	}
+
+	for _, v := range run_tests {
+		var stdout, stderr bytes.Buffer
+		code := run(v.args, strings.NewReader(""), &stdout, &stderr)
+		if code != v.code || stdout.String() != v.expected {
+			t.Errorf("Unexpected result of triego %s: got %d\n%s%s\nexpected %d\n%s", strings.Join(v.args, " "), code, stdout.String(), stderr.String(), v.code, v.expected)
+		}
+	}
+
+	// building from the standard input
+	changed := strings.Replace(words, "iran\t85", "iran\t86", 1)
+	changed = strings.Replace(changed, "iceland\t0.3\n", "", 1) + "india\n"
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"build", "-o", second}, strings.NewReader(changed), &stdout, &stderr); code != exit_ok {
+		t.Fatalf("Unexpected build failure: %s", stderr.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"diff", first, second}, nil, &stdout, &stderr); code != exit_no_match {
+		t.Errorf("Unexpected diff exit code: %d", code)
+	}
+	if expected := "- iceland\n+ india\n~ iran\n"; stdout.String() != expected {
+		t.Errorf("Unexpected diff output: got\n%s\nexpected\n%s", stdout.String(), expected)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"stats", second}, nil, &stdout, &stderr); code != exit_ok || !strings.Contains(stdout.String(), "words:      4\n") {
+		t.Errorf("Unexpected stats output: %s", stdout.String())
+	}
+}
+
+func Test_RunErrors(t *testing.T) {
+	dir := t.TempDir()
+	folded := filepath.Join(dir, "folded.bin")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"build", "-norm", "fold", "-o", folded}, strings.NewReader("Italy\n"), &stdout, &stderr); code != exit_ok {
+		t.Fatalf("Unexpected build failure: %s", stderr.String())
+	}
+
+	error_tests := [][]string{
+		{},
+		{"unknown"},
+		{"query", folded, "has"},
+		{"query", folded, "has", "italy"},
+		{"query", "-norm", "fold", folded, "unknown", "italy"},
+		{"stats", "-norm", "upper", folded},
+		{"stats", filepath.Join(dir, "missing.bin")},
+		{"build"},
+	}
+	for _, args := range error_tests {
+		stderr.Reset()
+		if code := run(args, strings.NewReader("italy\tmany\n"), &stdout, &stderr); code != exit_error || stderr.Len() == 0 {
+			t.Errorf("Unexpected result of triego %s: %d", strings.Join(args, " "), code)
+		}
+	}
+
+	stdout.Reset()
+	if code := run([]string{"query", "-norm", "fold", folded, "has", "ITALY"}, nil, &stdout, &stderr); code != exit_ok {
+		t.Errorf("Unexpected query result with folded keys: %d %s", code, stderr.String())
+	}
+}
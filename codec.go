@@ -0,0 +1,56 @@
+package triego
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// A Codec converts the values stored
+// in a radix tree to and from bytes
+// when the tree is serialized
+type Codec[V any] interface {
+	Marshal(value V) ([]byte, error)
+	Unmarshal(data []byte) (V, error)
+}
+
+// The default codec: values are
+// encoded using encoding/gob
+type GobCodec[V any] struct{}
+
+func (GobCodec[V]) Marshal(value V) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[V]) Unmarshal(data []byte) (value V, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+	return
+}
+
+// A compact codec for string values,
+// such as the phrases stored by AppendWord,
+// which are stored as raw bytes
+type StringCodec struct{}
+
+func (StringCodec) Marshal(value string) ([]byte, error) {
+	return []byte(value), nil
+}
+
+func (StringCodec) Unmarshal(data []byte) (string, error) {
+	return string(data), nil
+}
+
+// Returns the codec this radix tree
+// has been configured with or the
+// default gob based codec
+func (t *Trie[V]) codec() Codec[V] {
+	if c, ok := t.config().codec.(Codec[V]); ok {
+		return c
+	}
+
+	return GobCodec[V]{}
+}
@@ -0,0 +1,155 @@
+package triego
+
+import (
+	"container/heap"
+)
+
+// A Match describes a word found
+// in the radix tree: the key it is stored
+// with, its most recent value and its weight
+type Match[V any] struct {
+	Key    string
+	Value  V
+	Weight float64
+
+	// Distance is the edit distance
+	// between the key and the query
+	// of a fuzzy search
+	Distance int
+}
+
+// Inserts the given key like Insert
+// and assigns it the given weight, which is
+// used by Complete to rank completions
+func (t *Trie[V]) InsertWeighted(key string, value V, weight float64) {
+	k := t.key_runes(key)
+	if len(k) == 0 {
+		return
+	}
+
+	path := t.append_radix(k, func([]V, []float64) ([]V, []float64) {
+		return []V{value}, nil
+	})
+	path[len(path)-1].weight = weight
+	update_subtree(path)
+}
+
+// Returns at most limit words starting
+// with the given prefix, ordered by weight
+// from the highest to the lowest. Words
+// with the same weight are ordered by key.
+// A limit <= 0 returns all the matching words.
+// The search is best-first: each subtree is
+// explored only once its max weight is higher
+// than the weight of every completion still
+// to be returned, so that a short prefix
+// does not require walking the whole tree.
+func (t *Trie[V]) Complete(prefix string, limit int) []Match[V] {
+	matches := []Match[V]{}
+
+	node, key := t.prefix_node(t.key_runes(prefix))
+	if node == nil {
+		return matches
+	}
+
+	h := &completion_heap[V]{{node, key, node.maxWeight, false}}
+	for h.Len() > 0 && (limit <= 0 || len(matches) < limit) {
+		c := heap.Pop(h).(completion[V])
+		if c.word {
+			matches = append(matches, Match[V]{string(c.key), c.node.value(), c.node.weight, 0})
+			continue
+		}
+
+		if c.node.IsWord {
+			heap.Push(h, completion[V]{c.node, c.key, c.node.weight, true})
+		}
+		for _, child := range c.node.Children {
+			child_key := make([]rune, len(c.key), len(c.key)+len(child.chars))
+			copy(child_key, c.key)
+			child_key = append(child_key, child.chars...)
+			heap.Push(h, completion[V]{child, child_key, child.maxWeight, false})
+		}
+	}
+
+	return matches
+}
+
+// Returns the shallowest node whose
+// path starts with the given prefix
+// along with its whole path
+func (t *Trie[V]) prefix_node(prefix []rune) (*Trie[V], []rune) {
+	key := make([]rune, 0, len(prefix))
+	cn := t
+
+	for len(prefix) > 0 {
+		cn = cn.find_child(prefix[0])
+		if cn == nil {
+			return nil, nil
+		}
+
+		last := same_until(prefix, cn.chars)
+		key = append(key, cn.chars...)
+
+		// the prefix ends within
+		// this node or right at its end
+		if last == len(prefix)-1 {
+			return cn, key
+		}
+
+		// the prefix diverges
+		// from this node
+		if last < len(cn.chars)-1 {
+			return nil, nil
+		}
+		prefix = prefix[last+1:]
+	}
+
+	return cn, key
+}
+
+// A candidate completion: either a word
+// or a subtree still to be explored whose
+// weight is its max weight
+type completion[V any] struct {
+	node   *Trie[V]
+	key    []rune
+	weight float64
+	word   bool
+}
+
+type completion_heap[V any] []completion[V]
+
+func (h completion_heap[V]) Len() int {
+	return len(h)
+}
+
+func (h completion_heap[V]) Less(i, j int) bool {
+	if h[i].weight != h[j].weight {
+		return h[i].weight > h[j].weight
+	}
+
+	// the key of a subtree is never
+	// greater than the keys of its words
+	// so that ties are resolved by key
+	if c := compare_runes(h[i].key, h[j].key); c != 0 {
+		return c < 0
+	}
+
+	return h[i].word && !h[j].word
+}
+
+func (h completion_heap[V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *completion_heap[V]) Push(x interface{}) {
+	*h = append(*h, x.(completion[V]))
+}
+
+func (h *completion_heap[V]) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return c
+}
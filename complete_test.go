@@ -0,0 +1,113 @@
+package triego
+
+import (
+	"bufio"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type complete_test struct {
+	prefix   string
+	limit    int
+	expected []string
+}
+
+var complete_tests = []complete_test{
+	{"rom", 2, []string{"romulus", "romanus"}},
+	{"rom", 0, []string{"romulus", "romanus", "romane"}},
+	{"ru", 3, []string{"rubicon", "rubens", "ruber"}},
+	{"", 1, []string{"rubicon"}},
+	{"romanus", 5, []string{"romanus"}},
+	{"roma", 5, []string{"romanus", "romane"}},
+	{"x", 5, []string{}},
+}
+
+func Test_Complete(t *testing.T) {
+	trie := NewTrie[int]()
+	weights := map[string]float64{
+		"romane":     1,
+		"romanus":    5,
+		"romulus":    7,
+		"rubens":     3,
+		"ruber":      3,
+		"rubicon":    9,
+		"rubicundus": 2,
+	}
+	for w, weight := range weights {
+		trie.InsertWeighted(w, len(w), weight)
+	}
+
+	for _, v := range complete_tests {
+		matches := trie.Complete(v.prefix, v.limit)
+		keys := make([]string, 0, len(matches))
+		for _, m := range matches {
+			keys = append(keys, m.Key)
+			if m.Weight != weights[m.Key] || m.Value != len(m.Key) {
+				t.Errorf("Unexpected match for key '%s': got (%v, %v)", m.Key, m.Value, m.Weight)
+			}
+		}
+		if strings.Join(keys, ",") != strings.Join(v.expected, ",") {
+			t.Errorf("Unexpected Complete('%s', %d) result: got %v, expected %v", v.prefix, v.limit, keys, v.expected)
+		}
+	}
+}
+
+func Test_CompleteAfterRemoval(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.InsertWeighted("italy", "italy", 10)
+	trie.InsertWeighted("ita", "ita", 1)
+	trie.InsertWeighted("iran", "iran", 5)
+
+	trie.RemoveWord("italy")
+	matches := trie.Complete("i", 1)
+	if len(matches) != 1 || matches[0].Key != "iran" {
+		t.Errorf("Unexpected Complete('i', 1) result after removal: %v", matches)
+	}
+}
+
+func Test_CompleteCountries(t *testing.T) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	trie := NewTrie[string]()
+	weights := map[string]float64{}
+	scanner := bufio.NewScanner(file)
+	for i := 0; scanner.Scan(); i++ {
+		country := scanner.Text()
+		weight := float64((i * 7919) % 101)
+		trie.InsertWeighted(country, country, weight)
+		weights[country] = weight
+	}
+
+	for _, prefix := range []string{"", "A", "S", "Sa", "United", "Z", "Q"} {
+		expected := []string{}
+		for country := range weights {
+			if strings.HasPrefix(country, prefix) {
+				expected = append(expected, country)
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			if weights[expected[i]] != weights[expected[j]] {
+				return weights[expected[i]] > weights[expected[j]]
+			}
+			return expected[i] < expected[j]
+		})
+		if len(expected) > 5 {
+			expected = expected[:5]
+		}
+
+		matches := trie.Complete(prefix, 5)
+		got := make([]string, 0, len(matches))
+		for _, m := range matches {
+			got = append(got, m.Value)
+		}
+		if strings.Join(got, ",") != strings.Join(expected, ",") {
+			t.Errorf("Unexpected Complete('%s', 5) result: got %v, expected %v", prefix, got, expected)
+		}
+	}
+}
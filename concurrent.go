@@ -0,0 +1,242 @@
+package triego
+
+import (
+	"io"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// A radix tree safe for concurrent use
+// by multiple goroutines. Readers never
+// block: they access the latest snapshot
+// published by the writers, which are
+// serialized and publish a new snapshot
+// after each change. Thanks to structural
+// sharing a change copies just the nodes
+// in its path.
+type ConcurrentTrie[V any] struct {
+	mu       sync.Mutex
+	trie     *Trie[V]
+	snapshot atomic.Pointer[Trie[V]]
+}
+
+// Initializes a new concurrent radix tree
+// configured with the given options
+func NewConcurrentTrie[V any](opts ...Option) *ConcurrentTrie[V] {
+	c := &ConcurrentTrie[V]{trie: NewTrie[V](opts...)}
+	c.snapshot.Store(c.trie.Snapshot())
+
+	return c
+}
+
+// Applies the given change to the radix
+// tree and publishes a new snapshot of it
+func (c *ConcurrentTrie[V]) update(change func(t *Trie[V])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	change(c.trie)
+	c.snapshot.Store(c.trie.Snapshot())
+}
+
+// Returns a snapshot of the radix tree
+// as of the latest change. Changing the
+// returned radix tree does not affect
+// the ConcurrentTrie.
+func (c *ConcurrentTrie[V]) Snapshot() *Trie[V] {
+	// the published snapshot is never
+	// modified: its nodes can be shared
+	// without assigning it a new generation
+	return c.snapshot.Load().copy_root()
+}
+
+func (c *ConcurrentTrie[V]) AppendWord(phrase string) {
+	c.update(func(t *Trie[V]) {
+		t.AppendWord(phrase)
+	})
+}
+
+func (c *ConcurrentTrie[V]) AppendWords(words ...string) {
+	c.update(func(t *Trie[V]) {
+		t.AppendWords(words...)
+	})
+}
+
+func (c *ConcurrentTrie[V]) AppendPhrase(phrase string, value V, tokenizer Tokenizer) {
+	c.update(func(t *Trie[V]) {
+		t.AppendPhrase(phrase, value, tokenizer)
+	})
+}
+
+func (c *ConcurrentTrie[V]) AppendWeighted(phrase string, weight float64) {
+	c.update(func(t *Trie[V]) {
+		t.AppendWeighted(phrase, weight)
+	})
+}
+
+func (c *ConcurrentTrie[V]) Insert(key string, value V) {
+	c.update(func(t *Trie[V]) {
+		t.Insert(key, value)
+	})
+}
+
+func (c *ConcurrentTrie[V]) InsertWeighted(key string, value V, weight float64) {
+	c.update(func(t *Trie[V]) {
+		t.InsertWeighted(key, value, weight)
+	})
+}
+
+func (c *ConcurrentTrie[V]) Put(key string, value V) {
+	c.update(func(t *Trie[V]) {
+		t.Put(key, value)
+	})
+}
+
+// Like Trie.Update, fn is called while
+// holding the lock of the writers so that
+// the read-modify-write is atomic
+func (c *ConcurrentTrie[V]) Update(key string, fn func(old V, exists bool) V) {
+	c.update(func(t *Trie[V]) {
+		t.Update(key, fn)
+	})
+}
+
+func (c *ConcurrentTrie[V]) Increment(key string, delta float64) (weight float64, ok bool) {
+	c.update(func(t *Trie[V]) {
+		weight, ok = t.Increment(key, delta)
+	})
+	return
+}
+
+func (c *ConcurrentTrie[V]) RemoveWord(phrase string) (result bool) {
+	c.update(func(t *Trie[V]) {
+		result = t.RemoveWord(phrase)
+	})
+
+	return
+}
+
+func (c *ConcurrentTrie[V]) Delete(key string) (deleted bool) {
+	c.update(func(t *Trie[V]) {
+		deleted = t.Delete(key)
+	})
+	return
+}
+
+// The whole input is loaded before
+// publishing a new snapshot
+func (c *ConcurrentTrie[V]) LoadFrom(r io.Reader, opts LoadOptions) (err error) {
+	c.update(func(t *Trie[V]) {
+		err = t.LoadFrom(r, opts)
+	})
+	return
+}
+
+func (c *ConcurrentTrie[V]) ReadFrom(r io.Reader) (n int64, err error) {
+	c.update(func(t *Trie[V]) {
+		n, err = t.ReadFrom(r)
+	})
+
+	return
+}
+
+func (c *ConcurrentTrie[V]) HasWord(word string) bool {
+	return c.snapshot.Load().HasWord(word)
+}
+
+func (c *ConcurrentTrie[V]) Get(key string) (V, bool) {
+	return c.snapshot.Load().Get(key)
+}
+
+func (c *ConcurrentTrie[V]) GetAll(key string) []V {
+	return c.snapshot.Load().GetAll(key)
+}
+
+func (c *ConcurrentTrie[V]) Weight(key string) (float64, bool) {
+	return c.snapshot.Load().Weight(key)
+}
+
+// Returns all the values of the given key,
+// like GetAll, and its weight, both read
+// from the same snapshot. values is nil if
+// the key is not present.
+func (c *ConcurrentTrie[V]) Lookup(key string) (values []V, weight float64) {
+	s := c.snapshot.Load()
+	if values = s.GetAll(key); values != nil {
+		weight, _ = s.Weight(key)
+	}
+	return
+}
+
+func (c *ConcurrentTrie[V]) Words() []V {
+	return c.snapshot.Load().Words()
+}
+
+func (c *ConcurrentTrie[V]) ClosestWords(word string) []V {
+	return c.snapshot.Load().ClosestWords(word)
+}
+
+func (c *ConcurrentTrie[V]) Complete(prefix string, limit int) []Match[V] {
+	return c.snapshot.Load().Complete(prefix, limit)
+}
+
+func (c *ConcurrentTrie[V]) FuzzySearch(query string, maxDistance int) []Match[V] {
+	return c.snapshot.Load().FuzzySearch(query, maxDistance)
+}
+
+func (c *ConcurrentTrie[V]) LongestPrefix(s string) (string, V, bool) {
+	return c.snapshot.Load().LongestPrefix(s)
+}
+
+func (c *ConcurrentTrie[V]) AllPrefixesOf(s string) []Match[V] {
+	return c.snapshot.Load().AllPrefixesOf(s)
+}
+
+func (c *ConcurrentTrie[V]) Range(from, to string) []Match[V] {
+	return c.snapshot.Load().Range(from, to)
+}
+
+func (c *ConcurrentTrie[V]) Cursor() *Cursor[V] {
+	return c.snapshot.Load().Cursor()
+}
+
+func (c *ConcurrentTrie[V]) EachPrefix(callback PrefixIteratorCallback) {
+	c.snapshot.Load().EachPrefix(callback)
+}
+
+func (c *ConcurrentTrie[V]) All() iter.Seq2[string, V] {
+	return c.snapshot.Load().All()
+}
+
+func (c *ConcurrentTrie[V]) Keys() iter.Seq[string] {
+	return c.snapshot.Load().Keys()
+}
+
+func (c *ConcurrentTrie[V]) Values() iter.Seq[V] {
+	return c.snapshot.Load().Values()
+}
+
+func (c *ConcurrentTrie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return c.snapshot.Load().WithPrefix(prefix)
+}
+
+func (c *ConcurrentTrie[V]) Len() int {
+	return c.snapshot.Load().Len()
+}
+
+func (c *ConcurrentTrie[V]) CountWithPrefix(prefix string) int {
+	return c.snapshot.Load().CountWithPrefix(prefix)
+}
+
+func (c *ConcurrentTrie[V]) Stats() Stats {
+	return c.snapshot.Load().Stats()
+}
+
+func (c *ConcurrentTrie[V]) WriteTo(w io.Writer) (int64, error) {
+	return c.snapshot.Load().WriteTo(w)
+}
+
+func (c *ConcurrentTrie[V]) Export(w io.Writer) error {
+	return c.snapshot.Load().Export(w)
+}
@@ -0,0 +1,90 @@
+package triego
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Meant to be run with the race
+// detector enabled: go test -race
+func Test_ConcurrentTrie(t *testing.T) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	countries := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// appending each country once
+		if !slices.Contains(countries, scanner.Text()) {
+			countries = append(countries, scanner.Text())
+		}
+	}
+
+	trie := NewConcurrentTrie[string]()
+	var wg sync.WaitGroup
+
+	const writers = 4
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := i; j < len(countries); j += writers {
+				trie.AppendWord(countries[j])
+			}
+		}(i)
+	}
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, c := range countries {
+				trie.HasWord(c)
+				trie.Complete(c[:1], 3)
+				trie.ClosestWords(c)
+				trie.FuzzySearch(c, 1)
+				trie.EachPrefix(func(PrefixInfo) (bool, bool) {
+					return false, false
+				})
+
+				// each phrase adds 1 to the weight
+				// of its words: values and weight
+				// must come from the same snapshot
+				for _, w := range strings.Fields(c) {
+					if values, weight := trie.Lookup(w); weight != float64(len(values)) {
+						t.Errorf("Unexpected Lookup('%s') result: values %v, weight %v", w, values, weight)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range countries {
+		for _, w := range strings.Fields(c) {
+			if !trie.HasWord(w) {
+				t.Errorf("Unexpected: couldn't find word '%s' of '%s'", w, c)
+			}
+		}
+	}
+
+	wg.Add(len(countries))
+	for _, c := range countries {
+		go func(c string) {
+			defer wg.Done()
+			trie.RemoveWord(c)
+		}(c)
+	}
+	wg.Wait()
+
+	if words := trie.Words(); len(words) != 0 {
+		t.Errorf("Unexpected words left after removal: %v", words)
+	}
+}
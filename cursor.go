@@ -0,0 +1,278 @@
+package triego
+
+// A Cursor iterates over the words of
+// a radix tree in lexicographic order
+// of their keys, both forward and backward.
+// A new cursor is not positioned on any word:
+// Next moves it to the first word, Prev to the
+// last one and Seek to the first word whose key
+// is not lower than a given key. Moving past the
+// first or the last word leaves the cursor not
+// positioned again.
+// Changing the radix tree invalidates its
+// cursors: iterate over a Snapshot to keep
+// changing the radix tree meanwhile.
+type Cursor[V any] struct {
+	trie   *Trie[V]
+	frames []cursor_frame[V]
+	key    []rune
+	valid  bool
+}
+
+// A node in the path from the
+// root to the cursor position
+type cursor_frame[V any] struct {
+	node *Trie[V]
+
+	// the position of the node
+	// among its parent children
+	index int
+
+	// the length of the key
+	// of the parent node
+	key_len int
+}
+
+// Returns a new cursor over the words
+// of this radix tree
+func (t *Trie[V]) Cursor() *Cursor[V] {
+	c := &Cursor[V]{trie: t}
+	c.reset()
+
+	return c
+}
+
+// Returns true if the cursor is positioned on a word
+func (c *Cursor[V]) Valid() bool {
+	return c.valid
+}
+
+// Returns the key of the word the cursor is
+// positioned on, as stored in the radix tree
+func (c *Cursor[V]) Key() string {
+	if !c.valid {
+		return ""
+	}
+
+	return string(c.key)
+}
+
+// Returns the value of the word
+// the cursor is positioned on
+func (c *Cursor[V]) Value() (value V) {
+	if !c.valid {
+		return
+	}
+
+	return c.top().value()
+}
+
+// Moves the cursor to the next word
+// returning false if there is none
+func (c *Cursor[V]) Next() bool {
+	if !c.valid {
+		c.reset()
+	}
+
+	return c.next_word()
+}
+
+// Moves the cursor to the previous word
+// returning false if there is none
+func (c *Cursor[V]) Prev() bool {
+	if !c.valid {
+		// moving to the last node in
+		// pre-order, which is the last word
+		c.reset()
+		c.push_last()
+		if c.top().IsWord && !c.top().isRoot {
+			c.valid = true
+			return true
+		}
+	}
+
+	for c.retreat() {
+		if c.top().IsWord {
+			c.valid = true
+			return true
+		}
+	}
+
+	c.reset()
+	return false
+}
+
+// Moves the cursor to the first word
+// whose key is equal to or greater than
+// the given one, returning false if there
+// is none. The given key is normalized
+// like any other key.
+func (c *Cursor[V]) Seek(key string) bool {
+	c.reset()
+	k := c.trie.key_runes(key)
+
+	for len(k) > 0 {
+		cn := c.top()
+		i, found := cn.child_position(k[0])
+		if !found {
+			// all the children following
+			// position i have greater keys
+			if i < len(cn.Children) {
+				c.push(i)
+				return c.settle()
+			}
+			return c.skip()
+		}
+
+		child := cn.Children[i]
+		l := same_until(k, child.chars) + 1
+		c.push(i)
+
+		switch {
+		case l == len(child.chars):
+			// the child is a prefix of
+			// the key: keep descending
+			k = k[l:]
+		case l == len(k) || child.chars[l] > k[l]:
+			// all the keys in the child
+			// subtree are greater
+			return c.settle()
+		default:
+			// all the keys in the child
+			// subtree are lower
+			return c.skip()
+		}
+	}
+
+	return c.settle()
+}
+
+// Returns all the words whose keys are
+// equal to or greater than from and lower
+// than to, in lexicographic order.
+// An empty to stands for no upper bound.
+func (t *Trie[V]) Range(from, to string) []Match[V] {
+	matches := []Match[V]{}
+	upper := t.key_runes(to)
+
+	c := t.Cursor()
+	for ok := c.Seek(from); ok; ok = c.Next() {
+		if len(upper) > 0 && compare_runes(c.key, upper) >= 0 {
+			break
+		}
+		matches = append(matches, Match[V]{c.Key(), c.Value(), c.top().weight, 0})
+	}
+
+	return matches
+}
+
+func (c *Cursor[V]) reset() {
+	c.frames = append(c.frames[:0], cursor_frame[V]{c.trie, -1, 0})
+	c.key = c.key[:0]
+	c.valid = false
+}
+
+func (c *Cursor[V]) top() *Trie[V] {
+	return c.frames[len(c.frames)-1].node
+}
+
+// Moves the cursor to the i-th
+// child of the current node
+func (c *Cursor[V]) push(i int) {
+	child := c.top().Children[i]
+	c.frames = append(c.frames, cursor_frame[V]{child, i, len(c.key)})
+	c.key = append(c.key, child.chars...)
+}
+
+// Moves the cursor back to the
+// parent of the current node
+func (c *Cursor[V]) pop() cursor_frame[V] {
+	f := c.frames[len(c.frames)-1]
+	c.frames = c.frames[:len(c.frames)-1]
+	c.key = c.key[:f.key_len]
+
+	return f
+}
+
+// Moves the cursor to the last node
+// in pre-order of the current subtree
+func (c *Cursor[V]) push_last() {
+	for len(c.top().Children) > 0 {
+		c.push(len(c.top().Children) - 1)
+	}
+}
+
+// Moves the cursor to the next node in
+// pre-order, skipping the subtree of the
+// current node if skip is true. Returns
+// false if there is no such node.
+func (c *Cursor[V]) advance(skip bool) bool {
+	if !skip && len(c.top().Children) > 0 {
+		c.push(0)
+		return true
+	}
+
+	for len(c.frames) > 1 {
+		f := c.pop()
+		if f.index+1 < len(c.top().Children) {
+			c.push(f.index + 1)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Moves the cursor to the previous node
+// in pre-order. Returns false if there
+// is no such node other than the root.
+func (c *Cursor[V]) retreat() bool {
+	if len(c.frames) == 1 {
+		return false
+	}
+
+	f := c.pop()
+	if f.index > 0 {
+		c.push(f.index - 1)
+		c.push_last()
+		return true
+	}
+
+	return len(c.frames) > 1
+}
+
+// Positions the cursor on the first word
+// found from the current node on in pre-order
+func (c *Cursor[V]) settle() bool {
+	if n := c.top(); n.IsWord && !n.isRoot {
+		c.valid = true
+		return true
+	}
+
+	return c.next_word()
+}
+
+// Positions the cursor on the first word
+// following the current node in pre-order
+func (c *Cursor[V]) next_word() bool {
+	for c.advance(false) {
+		if c.top().IsWord {
+			c.valid = true
+			return true
+		}
+	}
+
+	c.reset()
+	return false
+}
+
+// Positions the cursor on the first
+// word following the current subtree
+func (c *Cursor[V]) skip() bool {
+	if !c.advance(true) {
+		c.reset()
+		return false
+	}
+
+	return c.settle()
+}
@@ -0,0 +1,113 @@
+package triego
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func Test_CursorNextPrev(t *testing.T) {
+	trie := NewTrie[string]()
+	words := random_corpus(2000)
+	trie.AppendWords(words...)
+
+	expected := trie.KeysOrdered(Lexicographic)
+
+	c := trie.Cursor()
+	forward := []string{}
+	for c.Next() {
+		forward = append(forward, c.Key())
+		if c.Value() != c.Key() {
+			t.Errorf("Unexpected value for key '%s': %s", c.Key(), c.Value())
+		}
+	}
+	if c.Valid() {
+		t.Errorf("Unexpected valid cursor after the last word")
+	}
+	if strings.Join(forward, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected forward iteration")
+	}
+
+	backward := []string{}
+	for c.Prev() {
+		backward = append(backward, c.Key())
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(expected)))
+	if strings.Join(backward, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected backward iteration")
+	}
+
+	// changing direction
+	trie = NewTrie[string]()
+	trie.AppendWords("a", "ab", "abc", "b")
+	c = trie.Cursor()
+	c.Seek("ab")
+	if c.Key() != "ab" || !c.Prev() || c.Key() != "a" || !c.Next() || c.Key() != "ab" || !c.Next() || c.Key() != "abc" {
+		t.Errorf("Unexpected position after changing direction: %s", c.Key())
+	}
+}
+
+type seek_test struct {
+	key      string
+	expected string
+}
+
+var seek_tests = []seek_test{
+	{"", "arma"},
+	{"arma", "arma"},
+	{"armb", "armento"},
+	{"arm", "arma"},
+	{"armatur", "armatura"},
+	{"armaz", "armento"},
+	{"b", "rom"},
+	{"roma", "romane"},
+	{"romanf", "romanus"},
+	{"romz", "rubens"},
+	{"rubz", "zoro"},
+	{"zoro", "zoro"},
+	{"zorro", ""},
+	{"zz", ""},
+}
+
+func Test_CursorSeek(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords(order_words...)
+	trie.AppendWords("armatura", "armento")
+
+	c := trie.Cursor()
+	for _, v := range seek_tests {
+		found := c.Seek(v.key)
+		if found != (v.expected != "") || c.Key() != v.expected {
+			t.Errorf("Unexpected Seek('%s') result: got (%v, '%s'), expected '%s'", v.key, found, c.Key(), v.expected)
+		}
+	}
+}
+
+type range_test struct {
+	from, to string
+	expected []string
+}
+
+var range_tests = []range_test{
+	{"ger", "gre", []string{"Germany", "Ghana"}},
+	{"ita", "itb", []string{"Italy"}},
+	{"za", "", []string{"Zambia", "New Zealand", "Zimbabwe"}},
+	{"zz", "", []string{}},
+	{"b", "a", []string{}},
+}
+
+func Test_Range(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase))
+	load_countries(trie, t)
+
+	for _, v := range range_tests {
+		matches := trie.Range(v.from, v.to)
+		values := make([]string, 0, len(matches))
+		for _, m := range matches {
+			values = append(values, m.Value)
+		}
+		if strings.Join(values, ",") != strings.Join(v.expected, ",") {
+			t.Errorf("Unexpected Range('%s', '%s') result: got %v, expected %v", v.from, v.to, values, v.expected)
+		}
+	}
+}
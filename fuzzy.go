@@ -0,0 +1,98 @@
+package triego
+
+import (
+	"sort"
+)
+
+// A node still to be explored by
+// FuzzySearch along with its whole key
+// and the edit distance row for it
+type fuzzy_frame[V any] struct {
+	node *Trie[V]
+	key  []rune
+	row  []int
+}
+
+// Returns all the words whose Levenshtein
+// distance from the given query is at most
+// maxDistance, ordered by distance, then by
+// weight from the highest to the lowest
+// and finally by key.
+// The radix tree is walked depth first
+// computing one row of the edit distance
+// matrix for each character: as soon as
+// every value in the row exceeds maxDistance
+// no word in the subtree can match and the
+// whole subtree is skipped.
+func (t *Trie[V]) FuzzySearch(query string, maxDistance int) []Match[V] {
+	q := t.key_runes(query)
+	matches := []Match[V]{}
+	if maxDistance < 0 {
+		return matches
+	}
+
+	// the distance between each prefix
+	// of the query and the empty string
+	first := make([]int, len(q)+1)
+	for i := range first {
+		first[i] = i
+	}
+
+	frames := []fuzzy_frame[V]{{t, []rune{}, first}}
+	for len(frames) > 0 {
+		f := frames[len(frames)-1]
+		frames = frames[:len(frames)-1]
+
+		for _, c := range f.node.Children {
+			row := f.row
+			for _, r := range c.chars {
+				row = next_distance_row(q, row, r)
+				if min(row...) > maxDistance {
+					break
+				}
+			}
+			if min(row...) > maxDistance {
+				continue
+			}
+
+			key := make([]rune, len(f.key), len(f.key)+len(c.chars))
+			copy(key, f.key)
+			key = append(key, c.chars...)
+
+			if c.IsWord && row[len(q)] <= maxDistance {
+				matches = append(matches, Match[V]{string(key), c.value(), c.weight, row[len(q)]})
+			}
+			frames = append(frames, fuzzy_frame[V]{c, key, row})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		if matches[i].Weight != matches[j].Weight {
+			return matches[i].Weight > matches[j].Weight
+		}
+		return matches[i].Key < matches[j].Key
+	})
+
+	return matches
+}
+
+// Computes the edit distance row for
+// a key extended with the given rune
+// starting from the row of the key itself
+func next_distance_row(query []rune, row []int, r rune) []int {
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+
+	for j := 1; j < len(row); j++ {
+		cost := 1
+		if query[j-1] == r {
+			cost = 0
+		}
+		next[j] = min(next[j-1]+1, row[j]+1, row[j-1]+cost)
+	}
+
+	return next
+}
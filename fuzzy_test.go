@@ -0,0 +1,59 @@
+package triego
+
+import (
+	"os"
+	"testing"
+)
+
+type fuzzy_test struct {
+	query       string
+	maxDistance int
+	expected    []string
+	distances   []int
+}
+
+var fuzzy_tests = []fuzzy_test{
+	{"Gremany", 2, []string{"Germany"}, []int{2}},
+	{"Germany", 0, []string{"Germany"}, []int{0}},
+	{"Itly", 1, []string{"Italy"}, []int{1}},
+	{"Iran", 1, []string{"Iran", "Iraq"}, []int{0, 1}},
+	{"Gremany", 1, []string{}, []int{}},
+	{"Mail", 2, []string{"Mali"}, []int{2}},
+	{"", 4, []string{"Chad", "Cuba", "Fiji", "Iran", "Iraq", "Laos", "Mali", "Oman", "Peru", "Togo"}, []int{4, 4, 4, 4, 4, 4, 4, 4, 4, 4}},
+}
+
+func Test_FuzzySearch(t *testing.T) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	trie := NewTrie[string]()
+	if err := trie.LoadFrom(file, LoadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range fuzzy_tests {
+		matches := trie.FuzzySearch(v.query, v.maxDistance)
+		if len(matches) != len(v.expected) {
+			t.Errorf("Unexpected FuzzySearch('%s', %d) result: got %v, expected %v", v.query, v.maxDistance, matches, v.expected)
+			continue
+		}
+		for i, m := range matches {
+			if m.Key != v.expected[i] || m.Value != v.expected[i] || m.Distance != v.distances[i] {
+				t.Errorf("Unexpected FuzzySearch('%s', %d) match %d: got (%s, %d), expected (%s, %d)", v.query, v.maxDistance, i, m.Key, m.Distance, v.expected[i], v.distances[i])
+			}
+		}
+	}
+}
+
+func Test_FuzzySearchNormalized(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase | StripDiacritics))
+	trie.AppendWords("Curaçao", "Réunion")
+
+	matches := trie.FuzzySearch("curacoa", 2)
+	if len(matches) != 1 || matches[0].Value != "Curaçao" {
+		t.Errorf("Unexpected FuzzySearch('curacoa', 2) result: %v", matches)
+	}
+}
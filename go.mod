@@ -0,0 +1,5 @@
+module github.com/typeflow/triego
+
+go 1.23
+
+require golang.org/x/text v0.21.0
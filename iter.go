@@ -0,0 +1,94 @@
+package triego
+
+import (
+	"iter"
+)
+
+// Returns an iterator over the keys and values
+// of all the words in the radix tree, visited
+// in the same order as Words.
+// Keys are yielded as stored, that is
+// tokenized and normalized.
+// The radix tree must not be changed while
+// iterating: iterate over a Snapshot to keep
+// changing the radix tree meanwhile.
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.each_word(nil, yield)
+	}
+}
+
+// Returns an iterator over the keys
+// of all the words in the radix tree
+func (t *Trie[V]) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		t.each_word(nil, func(key string, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// Returns an iterator over the values
+// of all the words in the radix tree
+func (t *Trie[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		t.each_word(nil, func(_ string, value V) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Returns an iterator over the keys and values
+// of the words starting with the given prefix.
+// The prefix is normalized like any other key.
+func (t *Trie[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		node, key := t.prefix_node(t.key_runes(prefix))
+		if node == nil {
+			return
+		}
+		node.each_word(key, yield)
+	}
+}
+
+// Calls yield for each word in the subtree
+// rooted at this node, whose key is the given
+// one, until yield returns false.
+// The DFS keeps, next to the stack of the
+// nodes to visit, the length of the key
+// of their parents so that the key of each
+// node can be rebuilt in place.
+func (t *Trie[V]) each_word(key []rune, yield func(string, V) bool) bool {
+	buf := make([]rune, len(key), len(key)+k_DEFAULT_ALLOC_SIZE)
+	copy(buf, key)
+
+	if t.IsWord && !t.isRoot && !yield(string(buf), t.value()) {
+		return false
+	}
+
+	stack := NewStack[V]()
+	parent_lengths := make([]int, 0, len(t.Children))
+
+	stack.Push(t.Children...)
+	for range t.Children {
+		parent_lengths = append(parent_lengths, len(buf))
+	}
+
+	for stack.Size() > 0 {
+		node := stack.Pop()
+		last := len(parent_lengths) - 1
+		buf = append(buf[:parent_lengths[last]], node.chars...)
+		parent_lengths = parent_lengths[:last]
+
+		if node.IsWord && !yield(string(buf), node.value()) {
+			return false
+		}
+
+		stack.Push(node.Children...)
+		for range node.Children {
+			parent_lengths = append(parent_lengths, len(buf))
+		}
+	}
+
+	return true
+}
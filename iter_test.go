@@ -0,0 +1,118 @@
+package triego
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_All(t *testing.T) {
+	trie := NewTrie[string]()
+	words := random_corpus(2000)
+	trie.AppendWords(words...)
+
+	keys := []string{}
+	for k, v := range trie.All() {
+		if k != v {
+			t.Errorf("Unexpected value for key '%s': %s", k, v)
+		}
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	if !slices.Equal(keys, trie.KeysOrdered(Lexicographic)) {
+		t.Errorf("Unexpected keys from All")
+	}
+
+	if !slices.Equal(slices.Collect(trie.Keys()), slices.Collect(trie.Values())) {
+		t.Errorf("Unexpected mismatch between Keys and Values")
+	}
+	if !slices.Equal(slices.Collect(trie.Values()), trie.Words()) {
+		t.Errorf("Unexpected mismatch between Values and Words")
+	}
+
+	// breaking early
+	count := 0
+	for range trie.All() {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Errorf("Unexpected number of iterations: %d", count)
+	}
+}
+
+type with_prefix_test struct {
+	prefix   string
+	expected []string
+}
+
+var with_prefix_tests = []with_prefix_test{
+	{"ita", []string{"italy"}},
+	{"IT", []string{"italy"}},
+	{"gu", []string{"guatemala", "guinea", "guinea-bissau", "guyana"}},
+	{"guinea", []string{"guinea", "guinea-bissau"}},
+	{"xyz", []string{}},
+}
+
+func Test_WithPrefix(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase))
+	load_countries(trie, t)
+
+	for _, v := range with_prefix_tests {
+		keys := []string{}
+		for k, value := range trie.WithPrefix(v.prefix) {
+			if found, _ := trie.Get(k); found != value {
+				t.Errorf("Unexpected value for key '%s': %s", k, value)
+			}
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		if !slices.Equal(keys, v.expected) {
+			t.Errorf("Unexpected WithPrefix('%s') result: got %v, expected %v", v.prefix, keys, v.expected)
+		}
+	}
+
+	// an empty prefix visits all the words
+	count := 0
+	for range trie.WithPrefix("") {
+		count++
+	}
+	if count != len(trie.KeysOrdered(Lexicographic)) {
+		t.Errorf("Unexpected number of words with an empty prefix: %d", count)
+	}
+}
+
+func Test_ManySiblings(t *testing.T) {
+	// more siblings than a page of the stack
+	// visiting the radix tree can take
+	runes := make([]rune, 4200)
+	for i := range runes {
+		runes[i] = 0x4e00 + rune(i)
+	}
+	trie := NewTrie[string](WithTokenizer(WordTokenizer{}))
+	trie.AppendWord(string(runes))
+
+	if keys := slices.Collect(trie.Keys()); len(keys) != len(runes) {
+		t.Errorf("Unexpected number of keys: got %d, expected %d", len(keys), len(runes))
+	}
+	count := 0
+	for range trie.WithPrefix("") {
+		count++
+	}
+	if count != len(runes) {
+		t.Errorf("Unexpected number of keys with the empty prefix: got %d, expected %d", count, len(runes))
+	}
+	if words := trie.Words(); len(words) != len(runes) {
+		t.Errorf("Unexpected number of words: got %d, expected %d", len(words), len(runes))
+	}
+
+	prefixes := 0
+	trie.EachPrefix(func(info PrefixInfo) (bool, bool) {
+		prefixes++
+		return false, false
+	})
+	if prefixes != len(runes) {
+		t.Errorf("Unexpected number of prefixes: got %d, expected %d", prefixes, len(runes))
+	}
+}
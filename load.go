@@ -0,0 +1,206 @@
+package triego
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// how often, in lines, LoadFrom
+	// reports its progress
+	k_LOAD_PROGRESS_LINES = 10000
+
+	k_COMMENT_PREFIX = "#"
+)
+
+var (
+	ErrInvalidLine = errors.New("triego: invalid line")
+	ErrInvalidKey  = errors.New("triego: key cannot be exported")
+)
+
+// LoadOptions configures how
+// LoadFrom reads its input
+type LoadOptions struct {
+	// Tokenize appends the key of each line
+	// as a phrase, like AppendPhrase, rather
+	// than storing it verbatim
+	Tokenize bool
+
+	// Comment is the prefix of the
+	// lines to skip, "#" if empty
+	Comment string
+
+	// Progress, if not nil, is called
+	// with the number of lines and bytes
+	// read so far every 10000 lines and
+	// once the input has been read
+	Progress func(lines int, bytes int64)
+}
+
+// A LoadError reports the line
+// of the input LoadFrom failed at
+type LoadError struct {
+	Line int
+	Err  error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("triego: line %d: %v", e.Line, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// Adds to the radix tree the keys read
+// from the given reader, one per line.
+// Each line holds a key optionally followed,
+// after a tab, by its weight and, after
+// another tab, by its value encoded in JSON:
+//
+//	key
+//	key<TAB>weight
+//	key<TAB>weight<TAB>json
+//
+// Blank lines and comments are skipped.
+// Keys are stored verbatim, each line adding
+// its value to the values of its key, and get
+// the weight of their line, 0 if missing.
+// When opts.Tokenize is true each key is
+// appended like AppendPhrase instead, the
+// weight of its word parts being incremented
+// by the weight of the line, 1 if missing.
+// Lines without a value use the key itself as
+// AppendWord does. The first invalid line stops
+// the loading, leaving the previous lines loaded,
+// and is reported by a *LoadError.
+func (t *Trie[V]) LoadFrom(r io.Reader, opts LoadOptions) error {
+	comment := opts.Comment
+	if comment == "" {
+		comment = k_COMMENT_PREFIX
+	}
+	default_weight := 0.0
+	if opts.Tokenize {
+		default_weight = 1
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, k_MAX_CHUNK_SIZE)
+
+	lines := 0
+	var bytes int64
+	for scanner.Scan() {
+		lines++
+		bytes += int64(len(scanner.Bytes())) + 1
+		if opts.Progress != nil && lines%k_LOAD_PROGRESS_LINES == 0 {
+			opts.Progress(lines, bytes)
+		}
+
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, comment) {
+			continue
+		}
+
+		key, value, weight, err := parse_line[V](line, default_weight)
+		if err != nil {
+			return &LoadError{lines, err}
+		}
+
+		if opts.Tokenize {
+			t.append_phrase(key, value, nil, weight)
+			continue
+		}
+
+		k := t.key_runes(key)
+		if len(k) == 0 {
+			continue
+		}
+		// the weight of the line replaces
+		// the one phrases added to the key
+		path := t.append_radix(k, func(values []V, _ []float64) ([]V, []float64) {
+			values, _ = t.add_value(values, nil, value, 0)
+			return values, nil
+		})
+		path[len(path)-1].weight = weight
+		update_subtree(path)
+	}
+	if err := scanner.Err(); err != nil {
+		return &LoadError{lines + 1, err}
+	}
+
+	if opts.Progress != nil {
+		opts.Progress(lines, bytes)
+	}
+
+	return nil
+}
+
+// Parses a line read by LoadFrom
+func parse_line[V any](line string, default_weight float64) (key string, value V, weight float64, err error) {
+	fields := strings.SplitN(line, "\t", 3)
+	key = fields[0]
+	value = phrase_value[V](key)
+	weight = default_weight
+
+	if len(fields) > 1 && fields[1] != "" {
+		if weight, err = strconv.ParseFloat(fields[1], 64); err != nil {
+			return key, value, weight, fmt.Errorf("%w: weight %q", ErrInvalidLine, fields[1])
+		}
+	}
+	if len(fields) > 2 {
+		if err = json.Unmarshal([]byte(fields[2]), &value); err != nil {
+			return key, value, weight, fmt.Errorf("%w: %v", ErrInvalidLine, err)
+		}
+	}
+
+	return
+}
+
+// Writes all the words of the radix tree to
+// the given writer in lexicographic order of
+// their keys, in the key<TAB>weight<TAB>json
+// format read by LoadFrom: a word with many
+// values takes a line for each of them.
+// Keys LoadFrom would not read back, those
+// containing tabs or line breaks, blank ones
+// and those starting with the "#" comment
+// prefix, cannot be exported and make Export
+// fail with ErrInvalidKey.
+func (t *Trie[V]) Export(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	var err error
+
+	t.walk(nil, false, func(key []rune, f walk_frame[V]) (bool, bool) {
+		n := f.node
+		if !n.IsWord || n.isRoot {
+			return false, false
+		}
+
+		k := string(key)
+		if strings.ContainsAny(k, "\t\r\n") || strings.TrimSpace(k) == "" || strings.HasPrefix(k, k_COMMENT_PREFIX) {
+			err = fmt.Errorf("%w: %q", ErrInvalidKey, k)
+			return false, true
+		}
+
+		weight := strconv.FormatFloat(n.weight, 'g', -1, 64)
+		for _, value := range n.data {
+			var data []byte
+			if data, err = json.Marshal(value); err != nil {
+				return false, true
+			}
+			fmt.Fprintf(bw, "%s\t%s\t%s\n", k, weight, data)
+		}
+
+		return false, false
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,174 @@
+package triego
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+type country struct {
+	Code       string
+	Population int
+}
+
+const countries_tsv = `# name	weight	payload
+Italy	59	{"Code":"IT","Population":59000000}
+
+France	68.2	{"Code":"FR","Population":68200000}` + "\r" + `
+Iceland
+Ireland	5
+`
+
+func Test_LoadFrom(t *testing.T) {
+	trie := NewTrie[country](WithNormalization(FoldCase))
+	if err := trie.LoadFrom(strings.NewReader(countries_tsv), LoadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if trie.Len() != 4 {
+		t.Errorf("Unexpected number of keys: %d", trie.Len())
+	}
+	if c, _ := trie.Get("france"); c.Code != "FR" || c.Population != 68200000 {
+		t.Errorf("Unexpected Get('france') result: %v", c)
+	}
+	if w, _ := trie.Weight("france"); w != 68.2 {
+		t.Errorf("Unexpected Weight('france') result: %v", w)
+	}
+	if c, ok := trie.Get("iceland"); !ok || c.Code != "" {
+		t.Errorf("Unexpected Get('iceland') result: (%v, %v)", c, ok)
+	}
+	if w, _ := trie.Weight("ireland"); w != 5 {
+		t.Errorf("Unexpected Weight('ireland') result: %v", w)
+	}
+	if trie.HasWord("# name") {
+		t.Errorf("Unexpected: comment loaded as a key")
+	}
+}
+
+type load_error_test struct {
+	input string
+	line  int
+}
+
+var load_error_tests = []load_error_test{
+	{"italy\t59\nfrance\tmany\n", 2},
+	{"# comment\n\nitaly\t59\t{\"Code\":}\n", 3},
+	{"italy\t59\t\"IT\"\n", 1},
+}
+
+func Test_LoadFromErrors(t *testing.T) {
+	for _, v := range load_error_tests {
+		trie := NewTrie[country]()
+		err := trie.LoadFrom(strings.NewReader(v.input), LoadOptions{})
+
+		var load_err *LoadError
+		if !errors.As(err, &load_err) || load_err.Line != v.line || !errors.Is(err, ErrInvalidLine) {
+			t.Errorf("Unexpected LoadFrom error: got %v, expected an error at line %d", err, v.line)
+		}
+	}
+
+	// a custom comment prefix
+	trie := NewTrie[string]()
+	if err := trie.LoadFrom(strings.NewReader("// italy\t59\nfrance\n"), LoadOptions{Comment: "//"}); err != nil {
+		t.Errorf("Unexpected LoadFrom error: %v", err)
+	}
+	if trie.Len() != 1 || !trie.HasWord("france") {
+		t.Errorf("Unexpected keys: %v", trie.KeysOrdered(Lexicographic))
+	}
+}
+
+func Test_LoadFromProgress(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 25000; i++ {
+		fmt.Fprintf(&input, "key%d\n", i)
+	}
+
+	calls := []int{}
+	var read int64
+	trie := NewTrie[string]()
+	err := trie.LoadFrom(strings.NewReader(input.String()), LoadOptions{
+		Progress: func(lines int, bytes int64) {
+			calls = append(calls, lines)
+			read = bytes
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(calls) != "[10000 20000 25000]" || read != int64(input.Len()) {
+		t.Errorf("Unexpected progress: %v lines, %d bytes", calls, read)
+	}
+}
+
+func Test_LoadFromTokenize(t *testing.T) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	loaded := NewTrie[string]()
+	if err := loaded.LoadFrom(file, LoadOptions{Tokenize: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	file.Seek(0, 0)
+	appended := NewTrie[string]()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		appended.AppendWord(scanner.Text())
+	}
+
+	if diff := compare_tries(appended, loaded); diff != "" {
+		t.Errorf("Unexpected difference between loaded and appended radix trees: %s", diff)
+	}
+}
+
+func Test_ExportLoadFrom(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase))
+	load_countries(trie, t)
+	trie.Increment("italy", 2.5)
+
+	var buf bytes.Buffer
+	if err := trie.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie[string](WithNormalization(FoldCase))
+	if err := loaded.LoadFrom(&buf, LoadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := compare_tries(trie, loaded); diff != "" {
+		t.Errorf("Unexpected difference after loading the exported radix tree: %s", diff)
+	}
+
+	// keys LoadFrom would skip or split are not exported
+	for _, key := range []string{"tab\tkey", "#hashtag", " "} {
+		invalid := NewTrie[string]()
+		invalid.Insert(key, "")
+		if err := invalid.Export(&buf); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Unexpected Export error for key %q: %v", key, err)
+		}
+	}
+
+	// the comment prefix is allowed past the first character
+	trie = NewTrie[string]()
+	trie.Insert("c#", "language")
+	trie.Insert(" #padded", "space")
+	buf.Reset()
+	if err := trie.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded = NewTrie[string]()
+	if err := loaded.LoadFrom(&buf, LoadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if diff := compare_tries(trie, loaded); diff != "" {
+		t.Errorf("Unexpected difference after loading the exported radix tree: %s", diff)
+	}
+}
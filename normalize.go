@@ -0,0 +1,79 @@
+package triego
+
+import (
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization flags control how keys
+// are normalized by the radix tree.
+// Flags can be combined: e.g.
+// FoldCase|NFC|StripDiacritics makes "Café",
+// "café" and "café" all match "cafe".
+type Normalization int
+
+const (
+	// Applies unicode full case folding
+	FoldCase Normalization = 1 << iota
+
+	// Applies the unicode canonical
+	// composition normal form
+	NFC
+
+	// Applies the unicode compatibility
+	// composition normal form. It takes
+	// precedence over NFC.
+	NFKC
+
+	// Removes all the combining
+	// marks from the keys
+	StripDiacritics
+)
+
+// Returns the given key normalized
+// as described by the configured
+// normalization flags
+func (o *options) normalize(key string) string {
+	n := o.normalization
+	if n == 0 {
+		return key
+	}
+
+	if n&StripDiacritics != 0 {
+		key = strip_diacritics(key)
+	}
+
+	if n&NFKC != 0 {
+		key = norm.NFKC.String(key)
+	} else if n&NFC != 0 {
+		key = norm.NFC.String(key)
+	}
+
+	if n&FoldCase != 0 {
+		// casers are stateful and
+		// cannot be shared
+		key = cases.Fold().String(key)
+	}
+
+	return key
+}
+
+// Returns the normalized runes
+// for the given key
+func (t *Trie[V]) key_runes(key string) []rune {
+	return []rune(t.config().normalize(key))
+}
+
+func strip_diacritics(key string) string {
+	decomposed := norm.NFD.String(key)
+	stripped := make([]rune, 0, len(decomposed))
+	for _, r := range decomposed {
+		if !unicode.Is(unicode.Mn, r) {
+			stripped = append(stripped, r)
+		}
+	}
+
+	return norm.NFC.String(string(stripped))
+}
@@ -0,0 +1,58 @@
+package triego
+
+import (
+	"testing"
+)
+
+type normalize_test struct {
+	normalization Normalization
+	key           string
+	expected      string
+}
+
+var normalize_tests = []normalize_test{
+	{0, "Café", "Café"},
+	{NFC, "café", "café"},
+	{FoldCase, "Straße", "strasse"},
+	{FoldCase | NFC, "CAFÉ", "café"},
+	{NFKC, "ﬁne", "fine"},
+	{StripDiacritics, "Ĉrème brûlée", "Creme brulee"},
+	{FoldCase | StripDiacritics, "Åland Islands", "aland islands"},
+}
+
+func Test_normalize(t *testing.T) {
+	for _, v := range normalize_tests {
+		o := default_options
+		o.normalization = v.normalization
+		if n := o.normalize(v.key); n != v.expected {
+			t.Errorf("Unexpected normalization of '%s': got '%s', expected '%s'", v.key, n, v.expected)
+		}
+	}
+}
+
+func Test_WithNormalization(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase | NFC | StripDiacritics))
+	trie.AppendWords("Côte d'Ivoire", "Curaçao", "Réunion")
+
+	lookups := map[string]string{
+		"cote":     "Côte d'Ivoire",
+		"CURACAO":  "Curaçao",
+		"reunion":  "Réunion",
+		"réunion": "Réunion",
+	}
+	for k, expected := range lookups {
+		if !trie.HasWord(k) {
+			t.Errorf("Unexpected: couldn't find word '%s'", k)
+		}
+		if v, ok := trie.Get(k); !ok || v != expected {
+			t.Errorf("Unexpected Get('%s') result: got (%v, %v), expected (%s, true)", k, v, ok, expected)
+		}
+	}
+
+	if words := trie.ClosestWords("cur"); len(words) != 1 || words[0] != "Curaçao" {
+		t.Errorf("Unexpected ClosestWords('cur') result: %v", words)
+	}
+	if !trie.RemoveWord("Réunion") || trie.HasWord("reunion") {
+		t.Errorf("Unexpected: couldn't remove phrase 'Réunion'")
+	}
+}
@@ -0,0 +1,90 @@
+package triego
+
+// An Option configures a radix
+// tree at construction time
+type Option func(*options)
+
+type options struct {
+	tokenizer     Tokenizer
+	normalization Normalization
+
+	// the maximum number of values
+	// kept for each key, 0 if unlimited
+	max_values int
+
+	// a Codec[V] for the values
+	// of the radix tree
+	codec interface{}
+}
+
+// Options used by radix trees
+// created without any Option
+var default_options = options{
+	tokenizer: space_tokenizer{},
+}
+
+// Configures the tokenizer used by
+// AppendWord, RemoveWord and AppendPhrase
+// to split phrases into word parts
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(o *options) {
+		if tokenizer != nil {
+			o.tokenizer = tokenizer
+		}
+	}
+}
+
+// Configures how keys are normalized
+// before being stored or looked up.
+// Values are never normalized so that
+// AppendWord still stores the original phrase.
+func WithNormalization(normalization Normalization) Option {
+	return func(o *options) {
+		o.normalization = normalization
+	}
+}
+
+// Configures the codec used by WriteTo
+// and ReadFrom to serialize the values
+// stored in the radix tree. The codec is
+// ignored by radix trees whose values
+// are not of type V.
+func WithCodec[V any](codec Codec[V]) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// Configures the maximum number of values
+// AppendWord and AppendPhrase keep for each
+// word part: the least recent ones are dropped
+// first. A max <= 0 means no limit, the default.
+func WithMaxValues(max int) Option {
+	return func(o *options) {
+		o.max_values = max
+	}
+}
+
+func new_options(opts []Option) *options {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	o := new(options)
+	*o = default_options
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// Returns the options this radix
+// tree has been configured with
+func (t *Trie[V]) config() *options {
+	if t.opts == nil {
+		return &default_options
+	}
+
+	return t.opts
+}
@@ -0,0 +1,191 @@
+package triego
+
+import (
+	"slices"
+)
+
+// Order describes the order in
+// which words and prefixes are visited
+type Order int
+
+const (
+	// The default order of Words
+	// and EachPrefix, which depends
+	// on the tree layout
+	Unordered Order = iota
+
+	// Ascending order of the keys
+	// compared rune by rune
+	Lexicographic
+
+	// Descending order of the keys
+	// compared rune by rune
+	ReverseLexicographic
+)
+
+// A node still to be visited by walk
+type walk_frame[V any] struct {
+	node *Trie[V]
+
+	// the length of the key of the
+	// parent node, that is how much of
+	// the key is shared with the parent
+	parent_len int
+	depth      int
+}
+
+// Visits in pre-order the subtree rooted at
+// this node, whose whole key is the given one,
+// calling visit with the key of every node.
+// Siblings are visited by ascending first rune
+// or by descending one if descending is true.
+// The key passed to visit is only valid until
+// visit returns.
+func (t *Trie[V]) walk(key []rune, descending bool, visit func(key []rune, f walk_frame[V]) (skip_subtree, halt bool)) {
+	buf := make([]rune, len(key), len(key)+k_DEFAULT_ALLOC_SIZE)
+	copy(buf, key)
+
+	frames := []walk_frame[V]{{t, len(key) - len(t.chars), 0}}
+	for len(frames) > 0 {
+		f := frames[len(frames)-1]
+		frames = frames[:len(frames)-1]
+		buf = append(buf[:f.parent_len], f.node.chars...)
+
+		skip, halt := visit(buf, f)
+		if halt {
+			return
+		}
+		if skip {
+			continue
+		}
+
+		// pushing children so that the
+		// first one to be visited is
+		// popped first
+		children := f.node.Children
+		for i := range children {
+			c := children[len(children)-1-i]
+			if descending {
+				c = children[i]
+			}
+			frames = append(frames, walk_frame[V]{c, len(buf), f.depth + 1})
+		}
+	}
+}
+
+// Returns the values of the words in the
+// subtree rooted at this node, whose key is
+// the given one, and the keys of the words
+// if keys is true, in the given order
+func (t *Trie[V]) ordered_words(key []rune, order Order, keys bool) (values []V, key_list []string) {
+	values = make([]V, 0)
+	key_list = make([]string, 0)
+
+	t.walk(key, false, func(k []rune, f walk_frame[V]) (bool, bool) {
+		if f.node.IsWord && !f.node.isRoot {
+			if keys {
+				key_list = append(key_list, string(k))
+			} else {
+				values = append(values, f.node.data...)
+			}
+		}
+		return false, false
+	})
+
+	// a pre-order visit of a radix tree
+	// with sorted children is lexicographic:
+	// reversing it is enough to obtain
+	// the reverse order
+	if order == ReverseLexicographic {
+		slices.Reverse(values)
+		slices.Reverse(key_list)
+	}
+
+	return
+}
+
+// Returns a list with all the words present
+// in the radix tree in the given order
+func (t *Trie[V]) WordsOrdered(order Order) []V {
+	if order == Unordered {
+		return t.Words()
+	}
+
+	values, _ := t.ordered_words(nil, order, false)
+	return values
+}
+
+// Returns the keys of all the words present
+// in the radix tree in the given order.
+// Keys are returned as stored, that is
+// after normalization. Unordered keys are
+// returned in lexicographic order.
+func (t *Trie[V]) KeysOrdered(order Order) []string {
+	_, keys := t.ordered_words(nil, order, true)
+	return keys
+}
+
+// Same as ClosestWords but the
+// words are returned in the given order
+func (t *Trie[V]) ClosestWordsOrdered(word string, order Order) []V {
+	if order == Unordered {
+		return t.ClosestWords(word)
+	}
+
+	node, key, exact := t.closest_node(t.key_runes(word))
+	switch {
+	case exact:
+		return slices.Clone(node.data)
+	case node == nil:
+		return []V{}
+	}
+
+	values, _ := node.ordered_words(key, order, false)
+	return values
+}
+
+// Same as ClosestWordsOrdered but the keys
+// of the words are returned instead of their
+// values. Unordered keys are returned in
+// lexicographic order.
+func (t *Trie[V]) ClosestKeysOrdered(word string, order Order) []string {
+	node, key, exact := t.closest_node(t.key_runes(word))
+	switch {
+	case exact:
+		return []string{string(key)}
+	case node == nil:
+		return []string{}
+	}
+
+	_, keys := node.ordered_words(key, order, true)
+	return keys
+}
+
+// Same as EachPrefix but the prefixes
+// are visited in the given order. In reverse
+// lexicographic order a prefix is still visited
+// before the longer prefixes it is part of,
+// so that its subtree can be skipped.
+func (t *Trie[V]) EachPrefixOrdered(order Order, callback PrefixIteratorCallback) {
+	if order == Unordered {
+		t.EachPrefix(callback)
+		return
+	}
+
+	t.walk(nil, order == ReverseLexicographic, func(key []rune, f walk_frame[V]) (bool, bool) {
+		if f.node.isRoot {
+			return false, false
+		}
+
+		return callback(PrefixInfo{
+			string(key),
+			f.node.IsWord,
+			f.depth,
+			f.parent_len,
+			f.node.weight,
+			f.node.words,
+			f.node.nodes,
+			f.node.maxWeight,
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package triego
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var order_words = []string{"romulus", "rubicon", "romane", "ruber", "rom", "romanus", "rubens", "zoro", "arma"}
+
+func Test_KeysOrdered(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords(order_words...)
+
+	expected := append([]string{}, order_words...)
+	sort.Strings(expected)
+	if keys := trie.KeysOrdered(Lexicographic); strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected lexicographic keys: got %v, expected %v", keys, expected)
+	}
+	if words := trie.WordsOrdered(Lexicographic); strings.Join(words, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected lexicographic words: got %v, expected %v", words, expected)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(expected)))
+	if keys := trie.KeysOrdered(ReverseLexicographic); strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected reverse lexicographic keys: got %v, expected %v", keys, expected)
+	}
+	if words := trie.WordsOrdered(ReverseLexicographic); strings.Join(words, ",") != strings.Join(expected, ",") {
+		t.Errorf("Unexpected reverse lexicographic words: got %v, expected %v", words, expected)
+	}
+}
+
+type closest_ordered_test struct {
+	query    string
+	order    Order
+	expected []string
+}
+
+var closest_ordered_tests = []closest_ordered_test{
+	{"ro", Lexicographic, []string{"rom", "romane", "romanus", "romulus"}},
+	{"ro", ReverseLexicographic, []string{"romulus", "romanus", "romane", "rom"}},
+	{"rub", Lexicographic, []string{"rubens", "ruber", "rubicon"}},
+	{"romanus", ReverseLexicographic, []string{"romanus"}},
+	{"x", Lexicographic, []string{}},
+}
+
+func Test_ClosestOrdered(t *testing.T) {
+	trie := NewTrie[string](WithNormalization(FoldCase))
+	for _, w := range order_words {
+		trie.Insert(w, strings.ToUpper(w))
+	}
+
+	for _, v := range closest_ordered_tests {
+		keys := trie.ClosestKeysOrdered(v.query, v.order)
+		if strings.Join(keys, ",") != strings.Join(v.expected, ",") {
+			t.Errorf("Unexpected ClosestKeysOrdered('%s') result: got %v, expected %v", v.query, keys, v.expected)
+		}
+
+		words := trie.ClosestWordsOrdered(v.query, v.order)
+		if strings.Join(words, ",") != strings.ToUpper(strings.Join(v.expected, ",")) {
+			t.Errorf("Unexpected ClosestWordsOrdered('%s') result: got %v, expected %v", v.query, words, v.expected)
+		}
+	}
+}
+
+func Test_EachPrefixOrdered(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("arma", "armatura", "armento", "zoro")
+
+	expected := map[Order]string{
+		Lexicographic:        "arm:1:0,arma:2:3,armatura:3:4,armento:2:3,zoro:1:0",
+		ReverseLexicographic: "zoro:1:0,arm:1:0,armento:2:3,arma:2:3,armatura:3:4",
+	}
+	for order, e := range expected {
+		prefixes := []string{}
+		trie.EachPrefixOrdered(order, func(info PrefixInfo) (skip_subtree, halt bool) {
+			prefixes = append(prefixes, fmt.Sprintf("%s:%d:%d", info.Prefix, info.Depth, info.SharedLength))
+			return false, false
+		})
+		if got := strings.Join(prefixes, ","); got != e {
+			t.Errorf("Unexpected prefixes in order %d: got %s, expected %s", order, got, e)
+		}
+	}
+
+	prefixes := []string{}
+	trie.EachPrefixOrdered(Lexicographic, func(info PrefixInfo) (skip_subtree, halt bool) {
+		prefixes = append(prefixes, info.Prefix)
+		return info.Prefix == "arma", info.Prefix == "armento"
+	})
+	if got := strings.Join(prefixes, ","); got != "arm,arma,armento" {
+		t.Errorf("Unexpected prefixes when skipping and halting: %s", got)
+	}
+}
@@ -0,0 +1,58 @@
+package triego
+
+// Returns the longest key stored in the radix tree
+// which is a prefix of the given string, along with
+// its value. ok is false if no key is a prefix of it.
+// The given string is normalized like any other key
+// but it is not tokenized, so that it can be used
+// for routing tables and dictionary-based segmentation.
+func (t *Trie[V]) LongestPrefix(s string) (key string, value V, ok bool) {
+	t.each_prefix_of(t.key_runes(s), func(k []rune, node *Trie[V]) {
+		key, value, ok = string(k), node.value(), true
+	})
+
+	return
+}
+
+// Returns all the words whose keys are prefixes
+// of the given string, from the shortest to the
+// longest. The given string is normalized like
+// any other key but it is not tokenized.
+func (t *Trie[V]) AllPrefixesOf(s string) []Match[V] {
+	matches := []Match[V]{}
+
+	t.each_prefix_of(t.key_runes(s), func(k []rune, node *Trie[V]) {
+		matches = append(matches, Match[V]{string(k), node.value(), node.weight, 0})
+	})
+
+	return matches
+}
+
+// Walks down from the root along the given
+// string calling visit with the key of each
+// word passed, until the string ends or
+// it diverges from the radix tree
+func (t *Trie[V]) each_prefix_of(s []rune, visit func(key []rune, node *Trie[V])) {
+	key := make([]rune, 0, len(s))
+	cn := t
+
+	for len(s) > 0 {
+		cn = cn.find_child(s[0])
+		if cn == nil {
+			return
+		}
+
+		// the whole node must be
+		// a prefix of the string
+		if len(cn.chars) > len(s) || same_until(s, cn.chars) != len(cn.chars)-1 {
+			return
+		}
+
+		key = append(key, cn.chars...)
+		s = s[len(cn.chars):]
+
+		if cn.IsWord {
+			visit(key, cn)
+		}
+	}
+}
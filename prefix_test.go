@@ -0,0 +1,85 @@
+package triego
+
+import (
+	"testing"
+)
+
+var routes = []string{
+	"/",
+	"/api",
+	"/api/v1",
+	"/api/v1/users",
+	"/api/v2",
+	"/static",
+}
+
+type longest_prefix_test struct {
+	input    string
+	expected string
+	prefixes []string
+}
+
+var longest_prefix_tests = []longest_prefix_test{
+	{"/api/v1/users/42", "/api/v1/users", []string{"/", "/api", "/api/v1", "/api/v1/users"}},
+	{"/api/v1", "/api/v1", []string{"/", "/api", "/api/v1"}},
+	{"/api/v", "/api", []string{"/", "/api"}},
+	{"/api/v3", "/api", []string{"/", "/api"}},
+	{"/static/logo.png", "/static", []string{"/", "/static"}},
+	{"/stat", "/", []string{"/"}},
+	{"api", "", []string{}},
+	{"", "", []string{}},
+}
+
+func Test_LongestPrefix(t *testing.T) {
+	trie := NewTrie[int]()
+	for i, r := range routes {
+		trie.Insert(r, i)
+	}
+
+	for _, v := range longest_prefix_tests {
+		key, value, ok := trie.LongestPrefix(v.input)
+		if ok != (v.expected != "") || key != v.expected {
+			t.Errorf("Unexpected LongestPrefix('%s') result: got (%s, %v), expected %s", v.input, key, ok, v.expected)
+		}
+		if ok && routes[value] != key {
+			t.Errorf("Unexpected value for key '%s': %d", key, value)
+		}
+
+		matches := trie.AllPrefixesOf(v.input)
+		if len(matches) != len(v.prefixes) {
+			t.Errorf("Unexpected AllPrefixesOf('%s') result: got %v, expected %v", v.input, matches, v.prefixes)
+			continue
+		}
+		for i, m := range matches {
+			if m.Key != v.prefixes[i] || routes[m.Value] != m.Key {
+				t.Errorf("Unexpected AllPrefixesOf('%s') match %d: %v", v.input, i, m)
+			}
+		}
+	}
+}
+
+func Test_LongestPrefixSegmentation(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("the", "then", "there", "rein", "in", "ere")
+
+	input := "thereinthen"
+	segments := []string{}
+	for len(input) > 0 {
+		key, _, ok := trie.LongestPrefix(input)
+		if !ok {
+			t.Fatalf("Unexpected missing prefix for '%s'", input)
+		}
+		segments = append(segments, key)
+		input = input[len(key):]
+	}
+
+	expected := []string{"there", "in", "then"}
+	if len(segments) != len(expected) {
+		t.Fatalf("Unexpected segmentation: %v", segments)
+	}
+	for i := range segments {
+		if segments[i] != expected[i] {
+			t.Errorf("Unexpected segmentation: %v", segments)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package triego
 
+import (
+	"reflect"
+	"slices"
+)
+
 func runes_eq(src, dst []rune) bool {
 	if len(src) != len(dst) {
 		return false
@@ -13,6 +18,29 @@ func runes_eq(src, dst []rune) bool {
 	return true
 }
 
+/*
+ * Compares two rune streams lexicographically
+ * returning -1, 0 or +1
+ */
+func compare_runes(src, dst []rune) int {
+	for i := 0; i < len(src) && i < len(dst); i++ {
+		if src[i] != dst[i] {
+			if src[i] < dst[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(src) < len(dst):
+		return -1
+	case len(src) > len(dst):
+		return 1
+	}
+	return 0
+}
+
 /*
  * Returns the last index at which both
  * streams are still equal
@@ -37,6 +65,32 @@ func same_until(src, dst []rune) (i int) {
 	return
 }
 
+/*
+ * Returns the given phrase as a value
+ * of type V when V is either string or
+ * interface{}, the zero value of V otherwise
+ */
+func phrase_value[V any](phrase string) (v V) {
+	switch p := any(&v).(type) {
+	case *string:
+		*p = phrase
+	case *interface{}:
+		*p = phrase
+	}
+
+	return
+}
+
+// Returns the index of the given value
+// within values or -1 if it is not there.
+// Values are compared with reflect.DeepEqual
+// since V is not necessarily comparable.
+func index_value[V any](values []V, value V) int {
+	return slices.IndexFunc(values, func(v V) bool {
+		return reflect.DeepEqual(v, value)
+	})
+}
+
 func max(args... int) (int) {
 	if len(args) == 0 {
 		panic("Cannot find max of empty list")
@@ -67,13 +121,13 @@ func min(args... int) (int) {
 
 const q_PAGE_SIZE = 4096 // common page size
 
-type queue struct {
-	q []*Trie
-	pages [][]*Trie
+type queue[V any] struct {
+	q []*Trie[V]
+	pages [][]*Trie[V]
 	h,t,page_index int
 }
 
-func (q *queue) enqueue(node *Trie) {
+func (q *queue[V]) enqueue(node *Trie[V]) {
 	if q.t == cap(q.q) {
 		// moving to the next page
 		q.page_index += 1
@@ -81,7 +135,7 @@ func (q *queue) enqueue(node *Trie) {
 		// incrementing pages slice
 		// if no empty pages are available
 		if q.page_index == len(q.pages) {
-			page := make([]*Trie, q_PAGE_SIZE)
+			page := make([]*Trie[V], q_PAGE_SIZE)
 			q.pages = append(q.pages, page)
 		}
 		q.q = q.pages[q.page_index]
@@ -94,11 +148,11 @@ func (q *queue) enqueue(node *Trie) {
 	q.t += 1
 }
 
-func (q *queue) is_empty() (bool) {
+func (q *queue[V]) is_empty() (bool) {
 	return q.h == q.t
 }
 
-func (q *queue) dequeue() (node *Trie) {
+func (q *queue[V]) dequeue() (node *Trie[V]) {
 	if q.h == q.t {
 		if q.page_index > 0 {
 			q.page_index -= 1
@@ -116,15 +170,15 @@ func (q *queue) dequeue() (node *Trie) {
 	return
 }
 
-func (q *queue) clear() {
+func (q *queue[V]) clear() {
 	q.h = 0
 	q.t = 0
 }
 
-func new_queue() *queue {
-	q := new(queue)
-	q.q = make([]*Trie, q_PAGE_SIZE)
-	q.pages = [][]*Trie{q.q}
+func new_queue[V any]() *queue[V] {
+	q := new(queue[V])
+	q.q = make([]*Trie[V], q_PAGE_SIZE)
+	q.pages = [][]*Trie[V]{q.q}
 	q.h = 0
 	q.t = 0
 	q.page_index = 0
@@ -53,10 +53,10 @@ var queue_tests = []queue_test {
 
 func Test_queue(t *testing.T) {
 	for _, v := range queue_tests {
-		q := new_queue()
+		q := new_queue[string]()
 		word := make([]rune, 0, len(v.w))
 		for _, c := range v.w {
-			t_ := NewTrie()
+			t_ := NewTrie[string]()
 			t_.chars = append(t_.chars, c)
 			q.enqueue(t_)
 		}
@@ -74,8 +74,8 @@ func Test_queue(t *testing.T) {
 
 func Benchmark_queue_enqueue(b *testing.B) {
 	b.ReportAllocs()
-	q := new_queue()
-	t := NewTrie()
+	q := new_queue[string]()
+	t := NewTrie[string]()
 	b.StopTimer()
 	b.ResetTimer()
 	b.StartTimer()
@@ -86,8 +86,8 @@ func Benchmark_queue_enqueue(b *testing.B) {
 
 func Benchmark_queue_dequeue(b *testing.B) {
 	b.ReportAllocs()
-	q := new_queue()
-	t := NewTrie()
+	q := new_queue[string]()
+	t := NewTrie[string]()
 	b.StopTimer()
 	for i := 0; i < b.N; i++ {
 		q.enqueue(t)
@@ -98,3 +98,31 @@ func Benchmark_queue_dequeue(b *testing.B) {
 		q.dequeue()
 	}
 }
+
+func Test_stack(t *testing.T) {
+	nodes := make([]*Trie[string], 3*s_DefaultAllocPageSize+10)
+	for i := range nodes {
+		nodes[i] = NewTrie[string]()
+	}
+
+	// pushes spanning many pages at once
+	// after partially filling the first one
+	s := NewStack[string]()
+	s.Push(nodes[:10]...)
+	s.Push(nodes[10:]...)
+	if s.Size() != len(nodes) {
+		t.Errorf("Unexpected stack size: got %d, expected %d", s.Size(), len(nodes))
+	}
+
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if top := s.Top(); top != nodes[i] {
+			t.Fatalf("Unexpected top element at position %d", i)
+		}
+		if n := s.Pop(); n != nodes[i] {
+			t.Fatalf("Unexpected element popped at position %d", i)
+		}
+	}
+	if s.Pop() != nil || s.Size() != 0 {
+		t.Errorf("Unexpected non empty stack")
+	}
+}
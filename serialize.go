@@ -0,0 +1,353 @@
+package triego
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// The binary format starts with a header made
+// of the k_FORMAT_MAGIC bytes, the format version
+// and the normalization flags of the radix tree.
+// Nodes follow in depth first pre-order, each
+// one encoded as:
+//
+//	uvarint  length of the node characters in bytes
+//	bytes    node characters, UTF-8 encoded
+//	byte     flags (k_FLAG_WORD)
+//	8 bytes  weight
+//	8 bytes  max weight of the subtree
+//	uvarint  number of values (words only)
+//	for each value (words only):
+//	uvarint  length of the encoded value
+//	bytes    value encoded by the codec
+//	8 bytes  weight added by the value
+//	uvarint  number of children
+//
+// Version 1 stores a single value for
+// each word, with no number of values.
+// Versions 1 and 2 do not store the
+// weight added by each value.
+const (
+	k_FORMAT_MAGIC   = "triego"
+	k_FORMAT_VERSION = 3
+
+	k_FLAG_WORD = 1 << 0
+
+	// sanity limit for lengths read
+	// from a serialized radix tree
+	k_MAX_CHUNK_SIZE = 1 << 26
+)
+
+var (
+	ErrInvalidFormat       = errors.New("triego: invalid serialized radix tree")
+	ErrUnsupportedVersion  = errors.New("triego: unsupported serialized radix tree version")
+	ErrNormalizationChange = errors.New("triego: serialized radix tree uses a different normalization")
+)
+
+// Writes the whole radix tree to the given
+// writer in a compact binary format preserving
+// node splits, words, weights and values.
+// Values are encoded with the codec configured
+// through WithCodec, encoding/gob by default.
+// Implements io.WriterTo.
+func (t *Trie[V]) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &count_writer{w: bufio.NewWriter(w)}
+	codec := t.codec()
+
+	cw.write([]byte(k_FORMAT_MAGIC))
+	cw.write_uvarint(k_FORMAT_VERSION)
+	cw.write_uvarint(uint64(t.config().normalization))
+
+	stack := NewStack[V]()
+	stack.Push(t)
+	for stack.Size() > 0 && cw.err == nil {
+		node := stack.Pop()
+
+		chars := []byte(string(node.chars))
+		cw.write_uvarint(uint64(len(chars)))
+		cw.write(chars)
+
+		var flags byte
+		if node.IsWord {
+			flags |= k_FLAG_WORD
+		}
+		cw.write([]byte{flags})
+		cw.write_float(node.weight)
+		cw.write_float(node.maxWeight)
+
+		if node.IsWord {
+			cw.write_uvarint(uint64(len(node.data)))
+			for i, value := range node.data {
+				data, err := codec.Marshal(value)
+				if err != nil {
+					return cw.n, err
+				}
+				cw.write_uvarint(uint64(len(data)))
+				cw.write(data)
+
+				var share float64
+				if len(node.shares) > i {
+					share = node.shares[i]
+				}
+				cw.write_float(share)
+			}
+		}
+
+		cw.write_uvarint(uint64(len(node.Children)))
+
+		// pushing children in reverse
+		// order so that they are popped
+		// in their actual order
+		for i := len(node.Children) - 1; i >= 0; i-- {
+			stack.Push(node.Children[i])
+		}
+	}
+
+	if cw.err == nil {
+		cw.err = cw.w.(*bufio.Writer).Flush()
+	}
+
+	return cw.n, cw.err
+}
+
+// A node being read whose
+// children are still to be read
+type read_frame[V any] struct {
+	node     *Trie[V]
+	children uint64
+}
+
+// Replaces the content of the radix tree
+// with the one read from the given reader,
+// as written by WriteTo. The radix tree must
+// be configured with the same normalization
+// and with a codec compatible with the one used
+// for writing. Implements io.ReaderFrom.
+func (t *Trie[V]) ReadFrom(r io.Reader) (n int64, err error) {
+	br, ok := r.(byte_reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	cr := &count_reader{r: br}
+	codec := t.codec()
+
+	magic := make([]byte, len(k_FORMAT_MAGIC))
+	cr.read(magic)
+	version := cr.read_uvarint()
+	normalization := Normalization(cr.read_uvarint())
+	switch {
+	case cr.err != nil:
+		// reported below
+	case string(magic) != k_FORMAT_MAGIC:
+		return cr.n, ErrInvalidFormat
+	case version < 1 || version > k_FORMAT_VERSION:
+		return cr.n, ErrUnsupportedVersion
+	case normalization != t.config().normalization:
+		return cr.n, ErrNormalizationChange
+	}
+
+	root := NewTrie[V]()
+	frames := []read_frame[V]{}
+	for cr.err == nil {
+		node := root
+		if len(frames) > 0 {
+			parent := &frames[len(frames)-1]
+			parent.children--
+
+			node = new(Trie[V])
+			node.gen = t.gen
+			parent.node.Children = append(parent.node.Children, node)
+		}
+
+		node.chars = []rune(string(cr.read_chunk()))
+		flags := cr.read_byte()
+		node.IsWord = flags&k_FLAG_WORD != 0
+		node.weight = cr.read_float()
+		node.maxWeight = cr.read_float()
+
+		if node.IsWord {
+			count := uint64(1)
+			if version > 1 {
+				count = cr.read_uvarint()
+			}
+			if count > k_MAX_CHUNK_SIZE {
+				return cr.n, ErrInvalidFormat
+			}
+
+			node.data = make([]V, 0, min(int(count), k_DEFAULT_ALLOC_SIZE))
+			for ; count > 0 && cr.err == nil; count-- {
+				data := cr.read_chunk()
+				if cr.err != nil {
+					break
+				}
+				value, err := codec.Unmarshal(data)
+				if err != nil {
+					return cr.n, err
+				}
+				node.data = append(node.data, value)
+				if version > 2 {
+					node.shares = append(node.shares, cr.read_float())
+				}
+			}
+			if cr.err != nil {
+				break
+			}
+		}
+
+		children := cr.read_uvarint()
+		if cr.err != nil {
+			break
+		}
+		if children > k_MAX_CHUNK_SIZE {
+			return cr.n, ErrInvalidFormat
+		}
+		if node != root {
+			if len(node.chars) == 0 {
+				return cr.n, ErrInvalidFormat
+			}
+			// lookups rely on children being
+			// sorted by their distinct first rune
+			siblings := frames[len(frames)-1].node.Children
+			if i := len(siblings) - 1; i > 0 && siblings[i-1].chars[0] >= node.chars[0] {
+				return cr.n, ErrInvalidFormat
+			}
+		}
+
+		if children > 0 {
+			node.Children = make([]*Trie[V], 0, min(int(children), k_DEFAULT_ALLOC_SIZE))
+			frames = append(frames, read_frame[V]{node, children})
+			continue
+		}
+		node.Children = make([]*Trie[V], 0)
+		update_subtree([]*Trie[V]{node})
+
+		// popping all the nodes
+		// whose children have been
+		// entirely read: their subtree
+		// statistics can be computed now
+		for len(frames) > 0 && frames[len(frames)-1].children == 0 {
+			update_subtree([]*Trie[V]{frames[len(frames)-1].node})
+			frames = frames[:len(frames)-1]
+		}
+		if len(frames) == 0 {
+			break
+		}
+	}
+
+	if cr.err != nil {
+		if cr.err == io.EOF {
+			cr.err = io.ErrUnexpectedEOF
+		}
+		return cr.n, cr.err
+	}
+
+	t.IsWord = root.IsWord
+	t.chars = root.chars
+	t.data = root.data
+	t.shares = root.shares
+	t.weight = root.weight
+	t.maxWeight = root.maxWeight
+	t.words = root.words
+	t.nodes = root.nodes
+	t.Children = root.Children
+
+	return cr.n, nil
+}
+
+// Keeps track of the bytes written
+// and of the first error occurred
+type count_writer struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *count_writer) write(p []byte) {
+	if cw.err != nil {
+		return
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+}
+
+func (cw *count_writer) write_uvarint(v uint64) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	cw.write(buf[:binary.PutUvarint(buf, v)])
+}
+
+func (cw *count_writer) write_float(f float64) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, math.Float64bits(f))
+	cw.write(buf)
+}
+
+type byte_reader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// Keeps track of the bytes read
+// and of the first error occurred
+type count_reader struct {
+	r   byte_reader
+	n   int64
+	err error
+}
+
+func (cr *count_reader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.n++
+	}
+	return b, err
+}
+
+func (cr *count_reader) read(p []byte) {
+	if cr.err != nil {
+		return
+	}
+	n, err := io.ReadFull(cr.r, p)
+	cr.n += int64(n)
+	cr.err = err
+}
+
+func (cr *count_reader) read_byte() (b byte) {
+	if cr.err != nil {
+		return
+	}
+	b, cr.err = cr.ReadByte()
+	return
+}
+
+func (cr *count_reader) read_uvarint() (v uint64) {
+	if cr.err != nil {
+		return
+	}
+	v, cr.err = binary.ReadUvarint(cr)
+	return
+}
+
+func (cr *count_reader) read_float() float64 {
+	buf := make([]byte, 8)
+	cr.read(buf)
+	return math.Float64frombits(binary.LittleEndian.Uint64(buf))
+}
+
+func (cr *count_reader) read_chunk() []byte {
+	size := cr.read_uvarint()
+	if cr.err != nil {
+		return nil
+	}
+	if size > k_MAX_CHUNK_SIZE {
+		cr.err = ErrInvalidFormat
+		return nil
+	}
+
+	chunk := make([]byte, size)
+	cr.read(chunk)
+	return chunk
+}
@@ -0,0 +1,217 @@
+package triego
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+/*
+ * Returns an error message describing the
+ * first difference found between two radix trees
+ */
+func compare_tries[V comparable](a, b *Trie[V]) string {
+	qa, qb := new_queue[V](), new_queue[V]()
+	qa.enqueue(a)
+	qb.enqueue(b)
+	for !qa.is_empty() && !qb.is_empty() {
+		na, nb := qa.dequeue(), qb.dequeue()
+		switch {
+		case string(na.chars) != string(nb.chars):
+			return "chars " + string(na.chars) + " != " + string(nb.chars)
+		case na.IsWord != nb.IsWord || !slices.Equal(na.data, nb.data):
+			return "word mismatch for node " + string(na.chars)
+		case na.weight != nb.weight || na.maxWeight != nb.maxWeight:
+			return "weight mismatch for node " + string(na.chars)
+		case len(na.Children) != len(nb.Children):
+			return "children count mismatch for node " + string(na.chars)
+		}
+		for i := range na.Children {
+			qa.enqueue(na.Children[i])
+			qb.enqueue(nb.Children[i])
+		}
+	}
+
+	return ""
+}
+
+func load_countries(trie *Trie[string], t testing.TB) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if err := trie.LoadFrom(file, LoadOptions{Tokenize: true}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_WriteToReadFrom(t *testing.T) {
+	for _, codec := range []Option{nil, WithCodec[string](StringCodec{})} {
+		opts := []Option{WithNormalization(FoldCase)}
+		if codec != nil {
+			opts = append(opts, codec)
+		}
+
+		trie := NewTrie[string](opts...)
+		load_countries(trie, t)
+		trie.InsertWeighted("vatican city", "Vatican City", 3.5)
+
+		var buf bytes.Buffer
+		written, err := trie.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != int64(buf.Len()) {
+			t.Errorf("Unexpected WriteTo count: got %d, expected %d", written, buf.Len())
+		}
+
+		loaded := NewTrie[string](opts...)
+		loaded.AppendWord("stale")
+		read, err := loaded.ReadFrom(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if read != written {
+			t.Errorf("Unexpected ReadFrom count: got %d, expected %d", read, written)
+		}
+
+		if diff := compare_tries(trie, loaded); diff != "" {
+			t.Errorf("Unexpected difference after loading: %s", diff)
+		}
+		if loaded.HasWord("stale") || !loaded.HasWord("ITALY") {
+			t.Errorf("Unexpected content after loading")
+		}
+	}
+}
+
+type record_codec struct{}
+
+func (record_codec) Marshal(r record) ([]byte, error) {
+	return []byte(strconv.Itoa(r.id)), nil
+}
+
+func (record_codec) Unmarshal(data []byte) (record, error) {
+	id, err := strconv.Atoi(string(data))
+	return record{id: id}, err
+}
+
+func Test_WriteToReadFromCodec(t *testing.T) {
+	trie := NewTrie[record](WithCodec[record](record_codec{}))
+	trie.Insert("rome", record{id: 1})
+	trie.Insert("romania", record{id: 2})
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie[record](WithCodec[record](record_codec{}))
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if diff := compare_tries(trie, loaded); diff != "" {
+		t.Errorf("Unexpected difference after loading: %s", diff)
+	}
+}
+
+type read_error_test struct {
+	data     []byte
+	expected error
+}
+
+func Test_ReadFromErrors(t *testing.T) {
+	var buf bytes.Buffer
+	trie := NewTrie[string]()
+	trie.AppendWords("arma", "armatura")
+	trie.WriteTo(&buf)
+	valid := buf.Bytes()
+
+	bad_version := append([]byte{}, valid...)
+	bad_version[len(k_FORMAT_MAGIC)] = k_FORMAT_VERSION + 1
+
+	// a root with too many children
+	header := append([]byte(k_FORMAT_MAGIC), k_FORMAT_VERSION, 0)
+	node := append([]byte{0, 0}, make([]byte, 16)...)
+	too_many := append(append([]byte{}, header...), node...)
+	too_many = binary.AppendUvarint(too_many, 1<<63)
+
+	// a root with the children 'b' and 'a'
+	unsorted := append(append([]byte{}, header...), node...)
+	unsorted = append(unsorted, 2)
+	for _, c := range []byte("ba") {
+		unsorted = append(unsorted, 1, c, 0)
+		unsorted = append(unsorted, make([]byte, 16)...)
+		unsorted = append(unsorted, 0)
+	}
+	// and one with two children starting with 'a'
+	shared := bytes.Replace(unsorted, []byte{1, 'b'}, []byte{1, 'a'}, 1)
+
+	read_error_tests := []read_error_test{
+		{[]byte("not a trie"), ErrInvalidFormat},
+		{bad_version, ErrUnsupportedVersion},
+		{valid[:len(valid)-3], io.ErrUnexpectedEOF},
+		{too_many, ErrInvalidFormat},
+		{unsorted, ErrInvalidFormat},
+		{shared, ErrInvalidFormat},
+	}
+
+	for _, v := range read_error_tests {
+		if _, err := NewTrie[string]().ReadFrom(bytes.NewReader(v.data)); err != v.expected {
+			t.Errorf("Unexpected ReadFrom error: got %v, expected %v", err, v.expected)
+		}
+	}
+
+	normalized := NewTrie[string](WithNormalization(NFC))
+	if _, err := normalized.ReadFrom(bytes.NewReader(valid)); err != ErrNormalizationChange {
+		t.Errorf("Unexpected ReadFrom error: got %v, expected %v", err, ErrNormalizationChange)
+	}
+}
+
+func Test_WriteToReadFromValues(t *testing.T) {
+	trie := NewTrie[string](WithCodec[string](StringCodec{}))
+	trie.AppendWords("new york", "york minster", "york")
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewTrie[string](WithCodec[string](StringCodec{}))
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if diff := compare_tries(trie, loaded); diff != "" {
+		t.Errorf("Unexpected difference after loading: %s", diff)
+	}
+	if values := loaded.GetAll("york"); len(values) != 3 {
+		t.Errorf("Unexpected values after loading: %v", values)
+	}
+}
+
+func Test_ReadFromVersion1(t *testing.T) {
+	// a radix tree holding the single
+	// word 'a' with value 'x', as written
+	// by the first version of the format
+	data := []byte(k_FORMAT_MAGIC)
+	data = append(data, 1, 0)
+	data = append(data, 0, 0)
+	data = append(data, make([]byte, 16)...)
+	data = append(data, 1)
+	data = append(data, 1, 'a', k_FLAG_WORD)
+	data = append(data, make([]byte, 16)...)
+	data = append(data, 1, 'x', 0)
+
+	trie := NewTrie[string](WithCodec[string](StringCodec{}))
+	if _, err := trie.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	if values := trie.GetAll("a"); len(values) != 1 || values[0] != "x" {
+		t.Errorf("Unexpected values after loading: %v", values)
+	}
+}
@@ -0,0 +1,243 @@
+package triego
+
+// Returns a new radix tree holding the words
+// of both this radix tree and the other one.
+// A key found in both gets the value returned
+// by conflict for their most recent values or,
+// if conflict is nil, the values of both. Its
+// weight is the sum of the two weights.
+// Both radix trees should be configured with
+// the same normalization; the new one is
+// configured like this radix tree.
+func (t *Trie[V]) Merge(other *Trie[V], conflict func(a, b V) V) *Trie[V] {
+	op := &set_operation[V]{keep_a: true, keep_b: true}
+	op.word = func(a, b *Trie[V]) ([]V, []float64, float64, bool) {
+		switch {
+		case a != nil && b != nil:
+			if conflict != nil {
+				return []V{conflict(a.value(), b.value())}, nil, a.weight + b.weight, true
+			}
+			data, shares := a.data, a.shares
+			for i, v := range b.data {
+				var share float64
+				if len(b.shares) > i {
+					share = b.shares[i]
+				}
+				data, shares = op.root.add_value(data, shares, v, share)
+			}
+			return data, shares, a.weight + b.weight, true
+		case a != nil:
+			return a.data, a.shares, a.weight, true
+		case b != nil:
+			return b.data, b.shares, b.weight, true
+		}
+		return nil, nil, 0, false
+	}
+
+	return op.apply(t, other)
+}
+
+// Returns a new radix tree holding the words
+// of this radix tree whose keys are also found
+// in the other one, with their values and weights
+// from this radix tree
+func (t *Trie[V]) Intersect(other *Trie[V]) *Trie[V] {
+	op := &set_operation[V]{}
+	op.word = func(a, b *Trie[V]) ([]V, []float64, float64, bool) {
+		if a != nil && b != nil {
+			return a.data, a.shares, a.weight, true
+		}
+		return nil, nil, 0, false
+	}
+
+	return op.apply(t, other)
+}
+
+// Returns a new radix tree holding the words
+// of this radix tree whose keys are not
+// found in the other one
+func (t *Trie[V]) Difference(other *Trie[V]) *Trie[V] {
+	op := &set_operation[V]{keep_a: true}
+	op.word = func(a, b *Trie[V]) ([]V, []float64, float64, bool) {
+		if a != nil && b == nil {
+			return a.data, a.shares, a.weight, true
+		}
+		return nil, nil, 0, false
+	}
+
+	return op.apply(t, other)
+}
+
+// A set operation between two radix trees,
+// a and b, building a new one
+type set_operation[V any] struct {
+	root *Trie[V]
+
+	// whether subtrees found only
+	// in either radix tree are kept
+	keep_a, keep_b bool
+
+	// Returns the values, their shares and the
+	// weight of the word of the new radix tree given
+	// the words with the same key in a and b, nil if
+	// missing. ok is false if there is no word.
+	word func(a, b *Trie[V]) (data []V, shares []float64, weight float64, ok bool)
+}
+
+// The part of a node label
+// starting at the given offset
+type edge[V any] struct {
+	node *Trie[V]
+	off  int
+}
+
+func (e edge[V]) label() []rune {
+	return e.node.chars[e.off:]
+}
+
+func child_edges[V any](n *Trie[V]) []edge[V] {
+	edges := make([]edge[V], len(n.Children))
+	for i, c := range n.Children {
+		edges[i] = edge[V]{c, 0}
+	}
+
+	return edges
+}
+
+// Walks the two radix trees in lockstep
+// building the new one, which is returned
+func (op *set_operation[V]) apply(a, b *Trie[V]) *Trie[V] {
+	op.root = NewTrie[V]()
+	op.root.opts = a.opts
+
+	op.root.Children = op.combine(child_edges(a), child_edges(b))
+	update_subtree([]*Trie[V]{op.root})
+
+	return op.root
+}
+
+// Combines two lists of sibling edges sorted
+// by first rune into the list of the nodes
+// of the new radix tree
+func (op *set_operation[V]) combine(as, bs []edge[V]) []*Trie[V] {
+	nodes := make([]*Trie[V], 0, len(as)+len(bs))
+	i, j := 0, 0
+
+	for i < len(as) || j < len(bs) {
+		var n *Trie[V]
+
+		switch {
+		case j == len(bs) || (i < len(as) && as[i].label()[0] < bs[j].label()[0]):
+			if op.keep_a {
+				n = op.copy(as[i], true)
+			}
+			i++
+		case i == len(as) || bs[j].label()[0] < as[i].label()[0]:
+			if op.keep_b {
+				n = op.copy(bs[j], false)
+			}
+			j++
+		default:
+			n = op.combine_edges(as[i], bs[j])
+			i++
+			j++
+		}
+
+		if n != nil {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// Combines two edges starting with the same
+// rune: the common part of their labels becomes
+// a node of the new radix tree whose children
+// are the combination of what follows it
+func (op *set_operation[V]) combine_edges(a, b edge[V]) *Trie[V] {
+	la, lb := a.label(), b.label()
+	common := same_until(la, lb) + 1
+
+	aw, ac := split_edge(a, common)
+	bw, bc := split_edge(b, common)
+
+	n := op.new_node(la[:common], aw, bw)
+	n.Children = op.combine(ac, bc)
+
+	return op.compact(n)
+}
+
+// Returns the word ending after the given
+// number of runes of the edge label, if any,
+// and the edges following it
+func split_edge[V any](e edge[V], at int) (*Trie[V], []edge[V]) {
+	if at < len(e.label()) {
+		return nil, []edge[V]{{e.node, e.off + at}}
+	}
+	if e.node.IsWord {
+		return e.node, child_edges(e.node)
+	}
+
+	return nil, child_edges(e.node)
+}
+
+// Copies the subtree of an edge found
+// in just one of the radix trees
+func (op *set_operation[V]) copy(e edge[V], from_a bool) *Trie[V] {
+	var word *Trie[V]
+	if e.node.IsWord {
+		word = e.node
+	}
+
+	var n *Trie[V]
+	if from_a {
+		n = op.new_node(e.label(), word, nil)
+	} else {
+		n = op.new_node(e.label(), nil, word)
+	}
+
+	n.Children = make([]*Trie[V], 0, len(e.node.Children))
+	for _, c := range e.node.Children {
+		if child := op.copy(edge[V]{c, 0}, from_a); child != nil {
+			n.Children = append(n.Children, child)
+		}
+	}
+
+	return op.compact(n)
+}
+
+func (op *set_operation[V]) new_node(chars []rune, a, b *Trie[V]) *Trie[V] {
+	n := new(Trie[V])
+	n.chars = make([]rune, len(chars))
+	copy(n.chars, chars)
+	n.gen = op.root.gen
+	n.data, n.shares, n.weight, n.IsWord = op.word(a, b)
+
+	return n
+}
+
+// Keeps the new radix tree compact: a node
+// which is not a word is dropped if it has
+// no children and merged with its only child
+// otherwise. Returns the resulting node, nil
+// if dropped, with its subtree statistics
+// up to date.
+func (op *set_operation[V]) compact(n *Trie[V]) *Trie[V] {
+	if !n.IsWord {
+		switch len(n.Children) {
+		case 0:
+			return nil
+		case 1:
+			c := n.Children[0]
+			chars := make([]rune, 0, len(n.chars)+len(c.chars))
+			chars = append(chars, n.chars...)
+			c.chars = append(chars, c.chars...)
+			return c
+		}
+	}
+
+	update_subtree([]*Trie[V]{n})
+
+	return n
+}
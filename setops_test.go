@@ -0,0 +1,133 @@
+package triego
+
+import (
+	"slices"
+	"testing"
+)
+
+/*
+ * Returns the key of the first node breaking the
+ * invariants of a compact radix tree: non-empty
+ * and sorted children and subtree statistics
+ */
+func check_compact[V any](trie *Trie[V]) (key string, ok bool) {
+	if key, ok := check_subtree(trie); !ok {
+		return key, false
+	}
+
+	q := new_queue[V]()
+	q.enqueue(trie)
+	for !q.is_empty() {
+		n := q.dequeue()
+		if !n.isRoot && !n.IsWord && len(n.Children) < 2 {
+			return string(n.chars), false
+		}
+		for i, c := range n.Children {
+			if len(c.chars) == 0 || (i > 0 && n.Children[i-1].chars[0] >= c.chars[0]) {
+				return string(c.chars), false
+			}
+			q.enqueue(c)
+		}
+	}
+
+	return "", true
+}
+
+type set_operation_test struct {
+	name     string
+	apply    func(a, b *Trie[string]) *Trie[string]
+	expected func(in_a, in_b bool) bool
+}
+
+var set_operation_tests = []set_operation_test{
+	{
+		"Merge",
+		func(a, b *Trie[string]) *Trie[string] { return a.Merge(b, nil) },
+		func(in_a, in_b bool) bool { return in_a || in_b },
+	},
+	{
+		"Intersect",
+		func(a, b *Trie[string]) *Trie[string] { return a.Intersect(b) },
+		func(in_a, in_b bool) bool { return in_a && in_b },
+	},
+	{
+		"Difference",
+		func(a, b *Trie[string]) *Trie[string] { return a.Difference(b) },
+		func(in_a, in_b bool) bool { return in_a && !in_b },
+	},
+}
+
+func Test_SetOperations(t *testing.T) {
+	words := random_corpus(4000)
+	words = append(words, order_words...)
+	a, b := NewTrie[string](), NewTrie[string]()
+	a.AppendWords(words[:2500]...)
+	a.AppendWords("rom", "romanus", "ruber")
+	b.AppendWords(words[1500:]...)
+	b.AppendWords("roman", "romane", "rubens")
+
+	a_keys, b_keys := a.KeysOrdered(Lexicographic), b.KeysOrdered(Lexicographic)
+	all := append(slices.Clone(a_keys), b_keys...)
+	slices.Sort(all)
+	all = slices.Compact(all)
+
+	for _, v := range set_operation_tests {
+		result := v.apply(a, b)
+
+		expected := []string{}
+		for _, k := range all {
+			_, in_a := slices.BinarySearch(a_keys, k)
+			_, in_b := slices.BinarySearch(b_keys, k)
+			if v.expected(in_a, in_b) {
+				expected = append(expected, k)
+			}
+		}
+
+		if keys := result.KeysOrdered(Lexicographic); !slices.Equal(keys, expected) {
+			t.Errorf("Unexpected %s keys: got %d, expected %d", v.name, len(keys), len(expected))
+		}
+		if key, ok := check_compact(result); !ok {
+			t.Errorf("Unexpected %s node '%s'", v.name, key)
+		}
+	}
+
+	// the operands are left untouched
+	if keys := a.KeysOrdered(Lexicographic); !slices.Equal(keys, a_keys) {
+		t.Errorf("Unexpected change of the first operand")
+	}
+	if keys := b.KeysOrdered(Lexicographic); !slices.Equal(keys, b_keys) {
+		t.Errorf("Unexpected change of the second operand")
+	}
+}
+
+func Test_MergeConflict(t *testing.T) {
+	base, custom := NewTrie[int](), NewTrie[int]()
+	base.InsertWeighted("rome", 1, 2)
+	base.Insert("romania", 2)
+	custom.InsertWeighted("rome", 10, 3)
+	custom.Insert("roma", 20)
+
+	merged := base.Merge(custom, func(a, b int) int { return a + b })
+	expected := map[string]int{"rome": 11, "romania": 2, "roma": 20}
+	for k, value := range expected {
+		if v, ok := merged.Get(k); !ok || v != value {
+			t.Errorf("Unexpected Get('%s') result: got (%d, %v), expected %d", k, v, ok, value)
+		}
+	}
+	if w, _ := merged.Weight("rome"); w != 5 {
+		t.Errorf("Unexpected merged weight: %v", w)
+	}
+	if merged.Len() != 3 {
+		t.Errorf("Unexpected merged Len(): %d", merged.Len())
+	}
+
+	// without a conflict function
+	// values are kept from both
+	phrases, other := NewTrie[string](), NewTrie[string]()
+	phrases.AppendWord("new york")
+	other.AppendWord("york minster")
+	values := phrases.Merge(other, nil).GetAll("york")
+	if !slices.Equal(values, []string{"new york", "york minster"}) {
+		t.Errorf("Unexpected merged values: %v", values)
+	}
+}
@@ -0,0 +1,46 @@
+package triego
+
+import (
+	"sync/atomic"
+)
+
+// The last generation assigned to a radix tree
+var last_generation atomic.Uint64
+
+func next_generation() uint64 {
+	return last_generation.Add(1)
+}
+
+// Returns a snapshot of the radix tree: later
+// changes to the radix tree are not visible
+// through the snapshot and vice versa.
+// Taking a snapshot is O(1): all the nodes are
+// shared between the radix tree and the snapshot
+// and a node is copied only when either of them
+// is about to modify it, so that an insertion or a
+// removal copies just the nodes in its path.
+// A snapshot is a radix tree itself and it can be
+// read concurrently with the changes to the
+// radix tree it has been taken from.
+func (t *Trie[V]) Snapshot() *Trie[V] {
+	s := t.copy_root()
+
+	// every existing node now belongs
+	// to an older generation and can
+	// no longer be modified in place
+	t.gen = next_generation()
+
+	return s
+}
+
+// Returns a new root of a new generation
+// sharing all the nodes of this radix tree
+func (t *Trie[V]) copy_root() *Trie[V] {
+	s := new(Trie[V])
+	*s = *t
+	s.Children = make([]*Trie[V], len(t.Children))
+	copy(s.Children, t.Children)
+	s.gen = next_generation()
+
+	return s
+}
@@ -0,0 +1,128 @@
+package triego
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func sorted_words(trie *Trie[string]) string {
+	words := trie.Words()
+	sort.Strings(words)
+	return strings.Join(words, ",")
+}
+
+func Test_Snapshot(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("romane", "romanus", "romulus", "rubens", "ruber")
+
+	snapshot := trie.Snapshot()
+	expected := sorted_words(snapshot)
+
+	// inserting, splitting, removing
+	// and merging nodes in the radix tree
+	trie.AppendWords("roman", "rubicon", "ro")
+	trie.RemoveWord("romulus")
+	trie.RemoveWord("rubens")
+	trie.InsertWeighted("romane", "ROMANE", 10)
+
+	if words := sorted_words(snapshot); words != expected {
+		t.Errorf("Unexpected snapshot words after changes: got %s, expected %s", words, expected)
+	}
+	if !snapshot.HasWord("romulus") || snapshot.HasWord("rubicon") {
+		t.Errorf("Unexpected snapshot content after changes")
+	}
+	if v, _ := snapshot.Get("romane"); v != "romane" {
+		t.Errorf("Unexpected snapshot value for 'romane': %s", v)
+	}
+
+	expected_trie := "ROMANE,ro,roman,romanus,ruber,rubicon"
+	if words := sorted_words(trie); words != expected_trie {
+		t.Errorf("Unexpected words after changes: got %s, expected %s", words, expected_trie)
+	}
+
+	// changing the snapshot does not
+	// affect the radix tree
+	snapshot.AppendWord("rubicundus")
+	snapshot.RemoveWord("romanus")
+	if trie.HasWord("rubicundus") || !trie.HasWord("romanus") {
+		t.Errorf("Unexpected radix tree content after changing the snapshot")
+	}
+}
+
+func Test_SnapshotSharing(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("arma", "armatura", "armento", "dom", "domani", "zoro")
+
+	snapshot := trie.Snapshot()
+	trie.AppendWord("domenica")
+
+	// only the path to the new
+	// word has been copied
+	for i, c := range trie.Children {
+		shared := c == snapshot.Children[i]
+		if shared != (c.chars[0] != 'd') {
+			t.Errorf("Unexpected sharing for node '%s': %v", string(c.chars), shared)
+		}
+	}
+}
+
+func Test_SnapshotConcurrentReads(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("arma", "armatura", "armento")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		snapshot := trie.Snapshot()
+		trie.AppendWords("armadillo", "armonia", "ar")
+		trie.RemoveWord("armatura")
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if words := sorted_words(snapshot); !strings.Contains(words, "armento") {
+				t.Errorf("Unexpected snapshot words: %s", words)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type depth_test struct {
+	word  string
+	depth int
+}
+
+// Checks the depth EachPrefix
+// reports for each prefix
+func check_depths(t *testing.T, trie *Trie[string], expected []depth_test) {
+	depths := map[string]int{}
+	trie.EachPrefix(func(info PrefixInfo) (bool, bool) {
+		depths[info.Prefix] = info.Depth
+		return false, false
+	})
+	if len(depths) != len(expected) {
+		t.Errorf("Unexpected prefixes: %v", depths)
+	}
+	for _, v := range expected {
+		if depth, ok := depths[v.word]; !ok || depth != v.depth {
+			t.Errorf("Unexpected depth for prefix '%s': got %d, expected %d", v.word, depth, v.depth)
+		}
+	}
+}
+
+func Test_Depth(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("romane", "romanus", "romulus")
+	check_depths(t, trie, []depth_test{{"rom", 1}, {"roman", 2}, {"romane", 3}, {"romanus", 3}, {"romulus", 2}})
+
+	// splitting a node shared with a snapshot
+	trie = NewTrie[string]()
+	trie.AppendWords("romane", "romanus")
+	snapshot := trie.Snapshot()
+	trie.AppendWord("rom")
+
+	check_depths(t, trie, []depth_test{{"rom", 1}, {"roman", 2}, {"romane", 3}, {"romanus", 3}})
+	check_depths(t, snapshot, []depth_test{{"roman", 1}, {"romane", 2}, {"romanus", 2}})
+}
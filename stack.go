@@ -6,10 +6,10 @@ package triego
 //
 // For the original visit http://github.com/alediaferia/stackgo
 
-type Stack struct {
+type Stack[V any] struct {
 	size int
-	currentPage []*Trie
-	pages [][]*Trie
+	currentPage []*Trie[V]
+	pages [][]*Trie[V]
 	offset int
 	capacity int
 	pageSize int
@@ -18,10 +18,10 @@ type Stack struct {
 
 const s_DefaultAllocPageSize = 4096
 
-func NewStack() *Stack {
-	stack := new(Stack)
-	stack.currentPage = make([]*Trie, s_DefaultAllocPageSize)
-	stack.pages = [][]*Trie{stack.currentPage}
+func NewStack[V any]() *Stack[V] {
+	stack := new(Stack[V])
+	stack.currentPage = make([]*Trie[V], s_DefaultAllocPageSize)
+	stack.pages = [][]*Trie[V]{stack.currentPage}
 	stack.offset = 0
 	stack.capacity = s_DefaultAllocPageSize
 	stack.pageSize = s_DefaultAllocPageSize
@@ -31,47 +31,33 @@ func NewStack() *Stack {
 	return stack
 }
 
-func (s *Stack) Push(elem... *Trie) {
+func (s *Stack[V]) Push(elem... *Trie[V]) {
 	if elem == nil || len(elem) == 0 {
 		return
 	}
 
-	if s.size == s.capacity {
-		pages_count := len(elem) / s.pageSize
-		if len(elem) % s.pageSize != 0 {
-			pages_count++
-		}
+	// allocating all the pages
+	// the elements will take
+	for s.capacity - s.size < len(elem) {
+		s.pages = append(s.pages, make([]*Trie[V], s.pageSize))
 		s.capacity += s.pageSize
+	}
 
-		s.currentPage = make([]*Trie, s.pageSize)
-		s.pages = append(s.pages, s.currentPage)
-		s.currentPageIndex++
-
-		pages_count--
-		for pages_count > 0 {
-			page := make([]*Trie, s.pageSize)
-			s.pages = append(s.pages, page)
+	for len(elem) > 0 {
+		if s.offset == len(s.currentPage) {
+			s.currentPageIndex++
+			s.currentPage = s.pages[s.currentPageIndex]
+			s.offset = 0
 		}
 
-		s.offset = 0
+		copied := copy(s.currentPage[s.offset:], elem)
+		s.offset += copied
+		s.size += copied
+		elem = elem[copied:]
 	}
-
-	available := len(s.currentPage) - s.offset
-	for len(elem) > available {
-		copy(s.currentPage[s.offset:], elem[:available])
-		s.currentPage = s.pages[s.currentPageIndex + 1]
-		s.currentPageIndex++
-		elem = elem[available:]
-		s.offset = 0
-		available = len(s.currentPage)
-	}
-
-	copy(s.currentPage[s.offset:], elem)
-	s.offset += len(elem)
-	s.size += len(elem)
 }
 
-func (s *Stack) Pop() (elem *Trie) {
+func (s *Stack[V]) Pop() (elem *Trie[V]) {
 	if s.size == 0 {
 		return nil
 	}
@@ -91,14 +77,14 @@ func (s *Stack) Pop() (elem *Trie) {
 	return
 }
 
-func (s *Stack) Top() (elem *Trie) {
+func (s *Stack[V]) Top() (elem *Trie[V]) {
 	if s.size == 0 {
 		return nil
 	}
 
 	off := s.offset - 1
 	if off < 0 {
-		page := s.pages[len(s.pages)-1]
+		page := s.pages[s.currentPageIndex-1]
 		elem = page[len(page)-1]
 		return
 	}
@@ -106,7 +92,7 @@ func (s *Stack) Top() (elem *Trie) {
 	return
 }
 
-func (s *Stack) Size() int {
+func (s *Stack[V]) Size() int {
 	return s.size
 }
 
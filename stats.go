@@ -0,0 +1,138 @@
+package triego
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// Stats describes the shape and the
+// memory footprint of a radix tree
+type Stats struct {
+	// Nodes does not include the root
+	Nodes int
+	Words int
+
+	// depths are measured in nodes:
+	// children of the root are at depth 1.
+	// AvgDepth is the average depth
+	// of the words.
+	MaxDepth int
+	AvgDepth float64
+
+	// Fanout maps a number of children
+	// to the number of nodes having
+	// that many children, root included
+	Fanout map[int]int
+
+	// the number of runes stored
+	// in the nodes and their size
+	Runes     int
+	RuneBytes int
+
+	// HeapBytes estimates the memory
+	// allocated for the nodes including
+	// the unused capacity of their slices.
+	// Memory referenced by the values
+	// is not accounted for, neither is
+	// the sharing with snapshots.
+	HeapBytes int
+}
+
+// Returns statistics about the
+// shape and the memory footprint
+// of the radix tree
+func (t *Trie[V]) Stats() Stats {
+	var value V
+	node_size := int(unsafe.Sizeof(*t))
+	rune_size := int(unsafe.Sizeof(rune(0)))
+	ptr_size := int(unsafe.Sizeof(t))
+	value_size := int(unsafe.Sizeof(value))
+	share_size := int(unsafe.Sizeof(float64(0)))
+
+	s := Stats{Fanout: map[int]int{}}
+	depths := 0
+
+	t.walk(nil, false, func(_ []rune, f walk_frame[V]) (bool, bool) {
+		n := f.node
+		s.Fanout[len(n.Children)]++
+		s.HeapBytes += node_size + cap(n.chars)*rune_size + cap(n.Children)*ptr_size + cap(n.data)*value_size + cap(n.shares)*share_size
+		if n.isRoot {
+			return false, false
+		}
+
+		s.Nodes++
+		s.Runes += len(n.chars)
+		s.MaxDepth = max(s.MaxDepth, f.depth)
+		if n.IsWord {
+			s.Words++
+			depths += f.depth
+		}
+
+		return false, false
+	})
+
+	s.RuneBytes = s.Runes * rune_size
+	if s.Words > 0 {
+		s.AvgDepth = float64(depths) / float64(s.Words)
+	}
+
+	return s
+}
+
+// Returns a human readable report
+// of the statistics
+func (s Stats) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "nodes:      %d\n", s.Nodes)
+	fmt.Fprintf(&b, "words:      %d\n", s.Words)
+	fmt.Fprintf(&b, "max depth:  %d\n", s.MaxDepth)
+	fmt.Fprintf(&b, "avg depth:  %.2f\n", s.AvgDepth)
+	fmt.Fprintf(&b, "runes:      %d (%d bytes)\n", s.Runes, s.RuneBytes)
+	fmt.Fprintf(&b, "heap bytes: %d\n", s.HeapBytes)
+	fmt.Fprintf(&b, "fanout:\n")
+
+	fanouts := make([]int, 0, len(s.Fanout))
+	for f := range s.Fanout {
+		fanouts = append(fanouts, f)
+	}
+	slices.Sort(fanouts)
+	for _, f := range fanouts {
+		fmt.Fprintf(&b, "  %4d children: %d nodes\n", f, s.Fanout[f])
+	}
+
+	return b.String()
+}
+
+// Returns the radix tree drawn one node
+// per line, each one indented according
+// to its depth and followed by '*' if
+// a word ends at it:
+//
+//	/
+//	  tri
+//	    al *
+//	    e *
+func (t *Trie[V]) String() string {
+	var b strings.Builder
+
+	t.walk(nil, false, func(_ []rune, f walk_frame[V]) (bool, bool) {
+		n := f.node
+		b.WriteString(strings.Repeat("  ", f.depth))
+		if n.isRoot {
+			b.WriteString("/")
+		} else {
+			b.WriteString(string(n.chars))
+		}
+		if n.IsWord && !n.isRoot {
+			b.WriteString(" *")
+		}
+		b.WriteString("\n")
+
+		return false, false
+	})
+
+	return b.String()
+}
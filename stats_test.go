@@ -0,0 +1,49 @@
+package triego
+
+import (
+	"testing"
+)
+
+func Test_Stats(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("trial", "trie", "trip", "tripod")
+
+	stats := trie.Stats()
+	if stats.Nodes != 5 || stats.Words != 4 {
+		t.Errorf("Unexpected node and word counts: %d, %d", stats.Nodes, stats.Words)
+	}
+	if stats.MaxDepth != 3 || stats.AvgDepth != 2.25 {
+		t.Errorf("Unexpected depths: %d, %f", stats.MaxDepth, stats.AvgDepth)
+	}
+	// tri, al, e, p, od
+	if stats.Runes != 9 || stats.RuneBytes != 36 {
+		t.Errorf("Unexpected runes: %d, %d", stats.Runes, stats.RuneBytes)
+	}
+	expected := map[int]int{0: 3, 1: 2, 3: 1}
+	for f, count := range expected {
+		if stats.Fanout[f] != count {
+			t.Errorf("Unexpected fanout histogram: %v", stats.Fanout)
+		}
+	}
+	if stats.HeapBytes <= stats.RuneBytes {
+		t.Errorf("Unexpected heap bytes: %d", stats.HeapBytes)
+	}
+	if stats.String() == "" {
+		t.Errorf("Unexpected empty report")
+	}
+
+	empty := NewTrie[string]().Stats()
+	if empty.Nodes != 0 || empty.Words != 0 || empty.AvgDepth != 0 || empty.Fanout[0] != 1 {
+		t.Errorf("Unexpected statistics for an empty radix tree: %v", empty)
+	}
+}
+
+func Test_String(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("trie", "trial", "tri")
+
+	expected := "/\n  tri *\n    al *\n    e *\n"
+	if s := trie.String(); s != expected {
+		t.Errorf("Unexpected String() result: got\n%s\nexpected\n%s", s, expected)
+	}
+}
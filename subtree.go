@@ -0,0 +1,51 @@
+package triego
+
+import (
+	"math"
+)
+
+// Recomputes the subtree statistics, that is
+// max weight, number of words and number of
+// nodes, of all the nodes in the given path
+// starting from the deepest one. The statistics
+// of their children must be up to date.
+func update_subtree[V any](path []*Trie[V]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		m := math.Inf(-1)
+		words, nodes := 0, 1
+		if n.IsWord && !n.isRoot {
+			m = n.weight
+			words = 1
+		}
+		for _, c := range n.Children {
+			if c.maxWeight > m {
+				m = c.maxWeight
+			}
+			words += c.words
+			nodes += c.nodes
+		}
+		n.maxWeight = m
+		n.words = words
+		n.nodes = nodes
+	}
+}
+
+// Returns the number of words
+// stored in the radix tree in O(1)
+func (t *Trie[V]) Len() int {
+	return t.words
+}
+
+// Returns the number of words starting
+// with the given prefix without visiting
+// them. The prefix is normalized like
+// any other key.
+func (t *Trie[V]) CountWithPrefix(prefix string) int {
+	node, _ := t.prefix_node(t.key_runes(prefix))
+	if node == nil {
+		return 0
+	}
+
+	return node.words
+}
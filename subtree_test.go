@@ -0,0 +1,97 @@
+package triego
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+/*
+ * Returns the key of the first node whose
+ * subtree statistics differ from the ones
+ * computed by visiting its subtree
+ */
+func check_subtree[V any](n *Trie[V]) (key string, ok bool) {
+	words, nodes := 0, 1
+	if n.IsWord && !n.isRoot {
+		words = 1
+	}
+	for _, c := range n.Children {
+		if key, ok := check_subtree(c); !ok {
+			return string(n.chars) + key, false
+		}
+		words += c.words
+		nodes += c.nodes
+	}
+
+	return string(n.chars), words == n.words && nodes == n.nodes
+}
+
+func Test_SubtreeStatistics(t *testing.T) {
+	trie := NewTrie[string]()
+	words := random_corpus(3000)
+	trie.AppendWords(words...)
+
+	snapshot := trie.Snapshot()
+	for _, w := range words[:1000] {
+		trie.RemoveWord(w)
+	}
+	trie.AppendWords(words[:rand.Intn(1000)]...)
+
+	for _, radix := range []*Trie[string]{trie, snapshot} {
+		if key, ok := check_subtree(radix); !ok {
+			t.Errorf("Unexpected subtree statistics for node '%s'", key)
+		}
+		if radix.Len() != len(radix.KeysOrdered(Lexicographic)) {
+			t.Errorf("Unexpected Len() result: %d", radix.Len())
+		}
+	}
+
+	var buf bytes.Buffer
+	trie.WriteTo(&buf)
+	loaded := NewTrie[string]()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if key, ok := check_subtree(loaded); !ok {
+		t.Errorf("Unexpected subtree statistics for node '%s' after loading", key)
+	}
+}
+
+type count_test struct {
+	prefix   string
+	expected int
+}
+
+var count_tests = []count_test{
+	{"", 9},
+	{"a", 1},
+	{"arm", 1},
+	{"armat", 0},
+	{"ro", 4},
+	{"roma", 2},
+	{"roman", 2},
+	{"romanu", 1},
+	{"x", 0},
+}
+
+func Test_CountWithPrefix(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords(order_words...)
+
+	if trie.Len() != 9 {
+		t.Errorf("Unexpected Len() result: got %d, expected 9", trie.Len())
+	}
+	for _, v := range count_tests {
+		if count := trie.CountWithPrefix(v.prefix); count != v.expected {
+			t.Errorf("Unexpected CountWithPrefix('%s') result: got %d, expected %d", v.prefix, count, v.expected)
+		}
+	}
+
+	trie.EachPrefix(func(info PrefixInfo) (bool, bool) {
+		if count := trie.CountWithPrefix(info.Prefix); info.Words != count {
+			t.Errorf("Unexpected word count for prefix '%s': got %d, expected %d", info.Prefix, info.Words, count)
+		}
+		return false, false
+	})
+}
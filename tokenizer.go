@@ -0,0 +1,167 @@
+package triego
+
+import (
+	"strings"
+	"unicode"
+)
+
+const k_DEFAULT_NGRAM_SIZE = 3
+
+// A Tokenizer splits a phrase into
+// the word parts to be stored in the
+// radix tree by AppendPhrase
+type Tokenizer interface {
+	Tokenize(phrase string) []string
+}
+
+// The TokenizerFunc type is an adapter
+// to allow the use of ordinary functions
+// as tokenizers
+type TokenizerFunc func(phrase string) []string
+
+func (f TokenizerFunc) Tokenize(phrase string) []string {
+	return f(phrase)
+}
+
+// The tokenizer historically used by
+// AppendWord: it splits the phrase on
+// single spaces dropping empty parts
+type space_tokenizer struct{}
+
+func (space_tokenizer) Tokenize(phrase string) []string {
+	words := strings.Split(phrase, k_WHITESPACE)
+	tokens := words[:0]
+	for _, w := range words {
+		if len(w) != 0 {
+			tokens = append(tokens, w)
+		}
+	}
+
+	return tokens
+}
+
+// Splits phrases on any sequence
+// of unicode white space characters
+// (spaces, tabs, new lines...)
+type WhitespaceTokenizer struct{}
+
+func (WhitespaceTokenizer) Tokenize(phrase string) []string {
+	return strings.Fields(phrase)
+}
+
+// Splits phrases at unicode word boundaries:
+// tokens are the maximal runs of letters, digits
+// and combining marks so that punctuation, hyphens
+// and slashes are dropped ("Wi-Fi router/AP" becomes
+// "Wi", "Fi", "router" and "AP").
+// Han, Hiragana and Katakana characters make
+// up a token each, since those scripts
+// do not separate words.
+type WordTokenizer struct{}
+
+func (WordTokenizer) Tokenize(phrase string) []string {
+	tokens := []string{}
+	start := -1
+
+	for i, r := range phrase {
+		switch {
+		case is_ideograph(r):
+			if start >= 0 {
+				tokens = append(tokens, phrase[start:i])
+				start = -1
+			}
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if start < 0 {
+				start = i
+			}
+		default:
+			if start >= 0 {
+				tokens = append(tokens, phrase[start:i])
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, phrase[start:])
+	}
+
+	return tokens
+}
+
+// Splits phrases at word boundaries like
+// WordTokenizer and then emits every character
+// n-gram of size N of each word, so that
+// words can be found by any of their inner
+// fragments. Words shorter than N are emitted
+// as they are. The zero value uses trigrams.
+type NGramTokenizer struct {
+	N int
+}
+
+func (tk NGramTokenizer) Tokenize(phrase string) []string {
+	n := tk.N
+	if n <= 0 {
+		n = k_DEFAULT_NGRAM_SIZE
+	}
+
+	tokens := []string{}
+	for _, w := range (WordTokenizer{}).Tokenize(phrase) {
+		runes := []rune(w)
+		if len(runes) <= n {
+			tokens = append(tokens, w)
+			continue
+		}
+		for i := 0; i+n <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+n]))
+		}
+	}
+
+	return tokens
+}
+
+// Splits identifiers written in CamelCase,
+// snake_case or kebab-case into their parts:
+// "parseHTTPRequest_v2" becomes "parse",
+// "HTTP", "Request" and "v2".
+// Characters keep their original case.
+type CaseTokenizer struct{}
+
+func (CaseTokenizer) Tokenize(phrase string) []string {
+	tokens := []string{}
+	for _, w := range (WordTokenizer{}).Tokenize(phrase) {
+		tokens = append(tokens, split_case(w)...)
+	}
+
+	return tokens
+}
+
+func is_ideograph(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// Splits a word right before an upper case
+// letter following a lower case one or a digit,
+// and right before the last upper case letter
+// of an acronym followed by a lower case one
+func split_case(word string) []string {
+	runes := []rune(word)
+	parts := []string{}
+	start := 0
+
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		if !unicode.IsUpper(cur) {
+			continue
+		}
+
+		camel := unicode.IsLower(prev) || unicode.IsDigit(prev)
+		acronym := unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if camel || acronym {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return append(parts, string(runes[start:]))
+}
@@ -0,0 +1,94 @@
+package triego
+
+import (
+	"strings"
+	"testing"
+)
+
+type append_phrase_test struct {
+	phrase    string
+	tokenizer Tokenizer
+	expected  []string
+	missing   []string
+}
+
+var append_phrase_tests = []append_phrase_test{
+	{
+		"dopo  domani",
+		nil,
+		[]string{"dopo", "domani"},
+		[]string{"dopo  domani", ""},
+	},
+	{
+		"wi-fi router",
+		TokenizerFunc(func(phrase string) []string {
+			return strings.FieldsFunc(phrase, func(r rune) bool {
+				return r == '-' || r == ' '
+			})
+		}),
+		[]string{"wi", "fi", "router"},
+		[]string{"wi-fi"},
+	},
+}
+
+func Test_AppendPhrase(t *testing.T) {
+	for _, v := range append_phrase_tests {
+		trie := NewTrie[int]()
+		trie.AppendPhrase(v.phrase, 42, v.tokenizer)
+
+		for _, w := range v.expected {
+			if value, ok := trie.Get(w); !ok || value != 42 {
+				t.Errorf("Unexpected Get('%s') result for phrase '%s': got (%v, %v), expected (42, true)", w, v.phrase, value, ok)
+			}
+		}
+		for _, w := range v.missing {
+			if trie.HasWord(w) {
+				t.Errorf("Unexpected: found word '%s' for phrase '%s'", w, v.phrase)
+			}
+		}
+	}
+}
+
+type tokenizer_test struct {
+	tokenizer Tokenizer
+	phrase    string
+	expected  []string
+}
+
+var tokenizer_tests = []tokenizer_test{
+	{WhitespaceTokenizer{}, "new\tyork  city\n", []string{"new", "york", "city"}},
+	{WordTokenizer{}, "Wi-Fi router/AP", []string{"Wi", "Fi", "router", "AP"}},
+	{WordTokenizer{}, "café, crème brûlée!", []string{"café", "crème", "brûlée"}},
+	{WordTokenizer{}, "東京タワー tokyo", []string{"東", "京", "タ", "ワ", "ー", "tokyo"}},
+	{NGramTokenizer{}, "italy ue", []string{"ita", "tal", "aly", "ue"}},
+	{NGramTokenizer{N: 2}, "rome", []string{"ro", "om", "me"}},
+	{CaseTokenizer{}, "parseHTTPRequest_v2", []string{"parse", "HTTP", "Request", "v2"}},
+	{CaseTokenizer{}, "snake_case-and-kebab", []string{"snake", "case", "and", "kebab"}},
+}
+
+func Test_tokenizers(t *testing.T) {
+	for _, v := range tokenizer_tests {
+		tokens := v.tokenizer.Tokenize(v.phrase)
+		if strings.Join(tokens, "|") != strings.Join(v.expected, "|") {
+			t.Errorf("Unexpected %T tokens for '%s': got %q, expected %q", v.tokenizer, v.phrase, tokens, v.expected)
+		}
+	}
+}
+
+func Test_WithTokenizer(t *testing.T) {
+	trie := NewTrie[string](WithTokenizer(WordTokenizer{}))
+	trie.AppendWord("Wi-Fi router/AP")
+
+	for _, w := range []string{"Wi", "Fi", "router", "AP"} {
+		if v, ok := trie.Get(w); !ok || v != "Wi-Fi router/AP" {
+			t.Errorf("Unexpected Get('%s') result: got (%v, %v), expected (Wi-Fi router/AP, true)", w, v, ok)
+		}
+	}
+
+	if !trie.RemoveWord("Wi-Fi router/AP") {
+		t.Errorf("Unexpected: couldn't remove phrase 'Wi-Fi router/AP'")
+	}
+	if words := trie.Words(); len(words) != 0 {
+		t.Errorf("Unexpected words left after removal: %v", words)
+	}
+}
@@ -2,8 +2,7 @@
 package triego
 
 import (
-	"github.com/alediaferia/stackgo"
-	"strings"
+	"slices"
 )
 
 const (
@@ -11,18 +10,55 @@ const (
 	k_WHITESPACE         = " "
 )
 
-type Trie struct {
+// A radix tree node holding
+// values of type V for its words.
+// Nodes can be shared between a radix tree
+// and its snapshots, which is why they do
+// not refer to their parent: a shared node
+// has a different one in each radix tree.
+type Trie[V any] struct {
 	IsWord   bool
-	Parent   *Trie
 	chars    []rune
-	Children []*Trie
+	Children []*Trie[V]
 	isRoot   bool
-	depth    int
-	data     interface{}
+	opts     *options
+
+	// the values of the word ending at
+	// this node, from the least recent
+	// to the most recent one. The slice
+	// may be shared with snapshots: it
+	// is replaced rather than modified.
+	data []V
+
+	// the weight each value added to the
+	// weight of the word when its phrase
+	// was appended, aligned with data.
+	// Replaced like data, it is nil if no
+	// phrase added weight to the word.
+	shares []float64
+
+	// the generation owning this node:
+	// a node can be modified in place
+	// only by the radix tree whose root
+	// has the same generation
+	gen uint64
+
+	// weight is the rank of the word
+	// ending at this node while maxWeight
+	// is the highest weight among all the
+	// words of the subtree rooted here
+	weight    float64
+	maxWeight float64
+
+	// the number of words and the number
+	// of nodes of the subtree rooted here,
+	// this node included
+	words int
+	nodes int
 }
 
-type TrieNode Trie
-type TriePtr *Trie
+type TrieNode[V any] Trie[V]
+type TriePtr[V any] *Trie[V]
 
 type PrefixInfo struct {
 	Prefix string
@@ -37,6 +73,19 @@ type PrefixInfo struct {
 	// of the previous prefix are shared
 	// with the current one
 	SharedLength int
+
+	// Weight of the word ending
+	// with this prefix, if any
+	Weight float64
+
+	// Statistics of the subtree rooted
+	// at this prefix, the prefix included:
+	// the number of words starting with
+	// the prefix, the number of nodes and
+	// the highest weight among the words
+	Words     int
+	Nodes     int
+	MaxWeight float64
 }
 
 // This call back is used by EachPrefix function
@@ -49,74 +98,187 @@ type PrefixInfo struct {
 type PrefixIteratorCallback func(PrefixInfo) (skip_subtree, halt bool)
 
 // Initializes a new radix tree
-func NewTrie() (t *Trie) {
-	t = new(Trie)
+// storing values of type V configured
+// with the given options
+func NewTrie[V any](opts ...Option) (t *Trie[V]) {
+	t = new(Trie[V])
 	t.IsWord = false
-	t.Parent = nil
 	t.chars = make([]rune, 0, k_DEFAULT_ALLOC_SIZE)
 	t.isRoot = true
-	t.Children = make([]*Trie, 0)
-	t.depth = 0
-	t.data = nil
+	t.Children = make([]*Trie[V], 0)
+	t.opts = new_options(opts)
+	t.gen = next_generation()
+	t.nodes = 1
 
 	return
 }
 
 // Returns true if this radix tree node is root
-func (t *Trie) IsRoot() bool {
+func (t *Trie[V]) IsRoot() bool {
 	return t.isRoot
 }
 
-func (t *TrieNode) IsRoot() bool {
+func (t *TrieNode[V]) IsRoot() bool {
 	return t.isRoot
 }
 
-// Returns the depth of the
-// node within the whole radix tree
-// it belongs to
-func (t *TrieNode) Depth() int {
-	return t.depth
-}
-
 // Appends a word to the trie
 // The algorithm follows the
 // BFS traversal principles implemented
 // iteratively. Given suffix is treated
 // as a full word.
-func (t *Trie) AppendWord(phrase string) {
-	words := strings.Split(phrase, k_WHITESPACE)
-	for _, w := range words {
-		if len(w) != 0 {
-			t.append_radix([]rune(w), phrase) // we are inserting the whole 'word' for each word part
+// The phrase itself is stored as the value
+// of each word part when V is either string
+// or interface{}, the zero value of V otherwise:
+// use Insert for attaching arbitrary values.
+// The weight of each word part is incremented
+// by 1 so that it counts how many times the
+// word part has been appended.
+func (t *Trie[V]) AppendWord(phrase string) {
+	t.AppendPhrase(phrase, phrase_value[V](phrase), nil)
+}
+
+// Appends every token the given tokenizer
+// extracts from the phrase associating
+// each of them with the given value and
+// incrementing its weight by 1.
+// A nil tokenizer stands for the tokenizer
+// the radix tree has been configured with,
+// which is also the one used by AppendWord.
+func (t *Trie[V]) AppendPhrase(phrase string, value V, tokenizer Tokenizer) {
+	t.append_phrase(phrase, value, tokenizer, 1)
+}
+
+func (t *Trie[V]) append_phrase(phrase string, value V, tokenizer Tokenizer, weight float64) {
+	if tokenizer == nil {
+		tokenizer = t.config().tokenizer
+	}
+
+	for _, w := range tokenizer.Tokenize(phrase) {
+		if key := t.key_runes(w); len(key) != 0 {
+			// we are adding the whole 'word' to the
+			// values of each word part
+			path := t.append_radix(key, func(values []V, shares []float64) ([]V, []float64) {
+				return t.add_value(values, shares, value, weight)
+			})
+			path[len(path)-1].weight += weight
+			update_subtree(path)
 		}
 	}
 }
 
-func (t *Trie) AppendWords(words ...string) {
+func (t *Trie[V]) AppendWords(words ...string) {
 	for _, w := range words {
 		t.AppendWord(w)
 	}
 }
 
-func (t *Trie) increase_depth() {
-	last_depth := t.depth
-	q := new_queue()
-	q.enqueue(t)
+// Inserts the given key in the trie
+// associating it with the given value.
+// Differently from AppendWord the key
+// is never split and is stored verbatim,
+// apart from the configured normalization.
+// Inserting an existing key replaces all
+// its values with the given one.
+func (t *Trie[V]) Insert(key string, value V) {
+	k := t.key_runes(key)
+	if len(k) == 0 {
+		return
+	}
+	t.append_radix(k, func([]V, []float64) ([]V, []float64) {
+		return []V{value}, nil
+	})
+}
+
+// Stores the given value for the given key
+// so that the radix tree can be used as a plain
+// map. It is equivalent to Insert.
+func (t *Trie[V]) Put(key string, value V) {
+	t.Insert(key, value)
+}
+
+// Replaces the values associated with the given
+// key with the one returned by fn, which is given
+// the most recent value and whether the key is
+// present. Like Insert the key is stored verbatim
+// and an absent key is inserted. The weight of an
+// existing key is preserved.
+func (t *Trie[V]) Update(key string, fn func(old V, exists bool) V) {
+	k := t.key_runes(key)
+	if len(k) == 0 {
+		return
+	}
 
-	for !q.is_empty() {
-		n := q.dequeue()
-		if n.depth > last_depth {
-			last_depth = n.depth
+	t.append_radix(k, func(values []V, _ []float64) ([]V, []float64) {
+		var old V
+		if len(values) > 0 {
+			old = values[len(values)-1]
 		}
+		return []V{fn(old, len(values) > 0)}, nil
+	})
+}
+
+// Removes a phrase previously added
+// with AppendWord. The phrase is removed
+// from the values of every word part of it:
+// word parts left without values are unmarked
+// and the radix tree is compacted again, the
+// others lose the weight appending the phrase
+// gave them.
+// Returns true if at least one word part
+// referred to the phrase.
+func (t *Trie[V]) RemoveWord(phrase string) (removed bool) {
+	value := phrase_value[V](phrase)
+
+	for _, w := range t.config().tokenizer.Tokenize(phrase) {
+		key := t.key_runes(w)
+		n := t.find_node(key)
+		if n == nil || !n.IsWord {
+			continue
+		}
+
+		i := index_value(n.data, value)
+		if i < 0 {
+			continue
+		}
+		removed = true
 
-		n.depth++
-		for _, c := range n.Children {
-			q.enqueue(c)
+		path := t.own_path(key)
+		if len(n.data) == 1 {
+			unmark_word(path)
+			continue
 		}
+
+		// a word part shared with other
+		// phrases keeps referring to them
+		n = path[len(path)-1]
+		n.data = slices.Delete(slices.Clone(n.data), i, i+1)
+		if len(n.shares) > i {
+			n.weight -= n.shares[i]
+			n.shares = slices.Delete(slices.Clone(n.shares), i, i+1)
+		}
+		update_subtree(path)
+	}
+
+	return
+}
+
+// Removes the given key, with all its values,
+// from the radix tree, which is compacted again.
+// Differently from RemoveWord the key is not
+// split: it is the counterpart of Insert.
+// Returns true if the key was present.
+func (t *Trie[V]) Delete(key string) bool {
+	k := t.key_runes(key)
+	if n := t.find_node(k); n == nil || !n.IsWord {
+		return false
 	}
+
+	unmark_word(t.own_path(k))
+	return true
 }
 
-func (t *Trie) delete_child(name string) {
+func (t *Trie[V]) delete_child(name string) {
 	l := len(t.Children)
 	for i := 0; i < l; i++ {
 		if string(t.Children[i].chars) == name {
@@ -130,276 +292,457 @@ func (t *Trie) delete_child(name string) {
 	}
 }
 
-// Inserts the given suffix in the trie associating
-// it with the given data
-func (t *Trie) append_radix(suffix []rune, data interface{}) {
-	cn := t
-	current_children := []*Trie{}
-	var last_node *Trie = nil
-
-	var e_range int = 0
-
-	for cn != nil && len(suffix) > 0 {
-		if !cn.isRoot {
-			// the current node is not a prefix
-			// for the current suffix so we skip
-			// it altogether
-			if suffix[0] != cn.chars[0] {
-				goto next
-			}
-
-			// how many characters does this node
-			// share with this suffix?
-			r := same_until(suffix, cn.chars)
-
-			// case 1:
-			// suffix and cn.chars completely
-			// match: we found the node already
-			// and we just have to make sure
-			// the node is marked as word already
-			// and contains the specified data
-			if r == len(cn.chars)-1 && len(suffix) == len(cn.chars) {
-				cn.IsWord = true
-				cn.data = data
-				return
-			}
-
-			// there is a partial match:
-			if r > -1 {
-				// storing this as the
-				// last visited node
-				last_node = cn
-
-				// now adjusting our
-				// given and temporary suffix
-				// before proceeding:
-				// they'll both start
-				// at the next character
-				suffix = suffix[r+1:]
-
-				// finally storing the last
-				// common index for both
-				// suffixes
-				e_range = r
-
-				// if the matching range is
-				// smaller than the amount of
-				// characters in the current node we are done searching
-				if r < len(cn.chars)-1 {
-					break
-				}
-			}
-		}
-		current_children = cn.Children
-	next:
-		if len(current_children) != 0 {
-			cn = current_children[0]
-			current_children = current_children[1:]
+// Returns the position of the child whose
+// characters start with the given rune and
+// whether such child exists. Children are
+// kept sorted by their first rune so that the
+// position is found with a binary search and,
+// when no child is found, it is the position
+// at which such a child should be inserted.
+func (t *Trie[V]) child_position(r rune) (i int, found bool) {
+	lo, hi := 0, len(t.Children)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if t.Children[m].chars[0] < r {
+			lo = m + 1
 		} else {
-			cn = nil
+			hi = m
 		}
 	}
 
-	// No node found matching
-	// part of the suffix we want
-	// to append. A new one will
-	// be created
-	if last_node == nil {
-		new_ := NewTrie()
-		new_.isRoot = false
-		new_.chars = make([]rune, len(suffix))
-		copy(new_.chars, suffix)
-		new_.Parent = t
-		t.Children = append(t.Children, new_)
-		new_.depth = t.depth + 1
-		new_.IsWord = true
-		new_.data = data
-		return
+	return lo, lo < len(t.Children) && t.Children[lo].chars[0] == r
+}
+
+// Returns the index of the child whose
+// characters start with the given rune
+// or -1 if no such child exists
+func (t *Trie[V]) child_index(r rune) int {
+	if i, found := t.child_position(r); found {
+		return i
+	}
+
+	return -1
+}
+
+// Returns the child whose characters
+// start with the given rune or nil
+// if no such child exists
+func (t *Trie[V]) find_child(r rune) *Trie[V] {
+	if i := t.child_index(r); i >= 0 {
+		return t.Children[i]
 	}
 
-	// last_node now will contain the node
-	// which constructs the closest match
-	// to the suffix we are about to append
-	//
-	// we now need to split the matching node
-	// content so that we can add our suffix
-
-	// now adjusting current node
-	// characters:
-	// if current node is 'romane'
-	// and we are about to append
-	// word 'romanus' we want to preserve
-	// just up to 'roman' and create 2 subnodes
-	// 'e' and 'us' respectively
-	orig_size := len(last_node.chars)
-
-	left := last_node.chars[:e_range+1] // will become the content of last_node
-	sub1 := last_node.chars[e_range+1:] // will become a new sub node
-	sub2 := suffix                      // new sub node as well
-
-	last_node.chars = left
-
-	was_word := last_node.IsWord
-
-	if len(suffix) == 0 {
-		last_node.IsWord = true
-	} else if e_range+1 != orig_size {
-		last_node.IsWord = false
+	return nil
+}
+
+// Returns a new child of this node owned
+// by the given generation and holding
+// a copy of the given characters
+func (t *Trie[V]) add_child(chars []rune, gen uint64) *Trie[V] {
+	c := new(Trie[V])
+	c.isRoot = false
+	c.chars = make([]rune, len(chars))
+	copy(c.chars, chars)
+	c.Children = make([]*Trie[V], 0, 1)
+	c.gen = gen
+
+	// keeping children sorted
+	i, _ := t.child_position(chars[0])
+	t.Children = append(t.Children, nil)
+	copy(t.Children[i+1:], t.Children[i:])
+	t.Children[i] = c
+
+	return c
+}
+
+// Returns the i-th child of this node making
+// sure it is owned by the given generation:
+// a child shared with a snapshot is copied
+// and replaced with its copy so that it can
+// be modified without affecting the snapshot.
+// This node must be owned by the given generation.
+func (t *Trie[V]) own_child(i int, gen uint64) *Trie[V] {
+	c := t.Children[i]
+	if c.gen == gen {
+		return c
+	}
+
+	// the children of the copy
+	// are shared as well
+	owned := new(Trie[V])
+	*owned = *c
+	owned.gen = gen
+	owned.Children = make([]*Trie[V], len(c.Children), len(c.Children)+1)
+	copy(owned.Children, c.Children)
+	t.Children[i] = owned
+
+	return owned
+}
+
+// Splits this node so that it keeps
+// just its first 'at' characters: the
+// remaining ones are moved to a new child
+// which inherits word, value and children.
+// If the current node is 'romane' and we are
+// about to append word 'romanus' we want to
+// preserve just up to 'roman' and create the
+// subnode 'e', while 'us' will be appended later
+func (t *Trie[V]) split(at int, gen uint64) {
+	sub := new(Trie[V])
+	sub.isRoot = false
+	sub.chars = t.chars[at:]
+	sub.IsWord = t.IsWord
+	sub.data = t.data
+	sub.shares = t.shares
+	sub.weight = t.weight
+	sub.maxWeight = t.maxWeight
+	sub.words = t.words
+	sub.nodes = t.nodes
+	sub.gen = gen
+
+	// an important thing to remember is that
+	// sub inherits all the children from
+	// the node which is being split
+	sub.Children = t.Children
+
+	t.chars = t.chars[:at]
+	t.IsWord = false
+	t.data = nil
+	t.shares = nil
+	t.weight = 0
+	t.Children = []*Trie[V]{sub}
+}
+
+// Merges the only child of this node
+// into the node itself so that the radix
+// stays compact after a removal:
+// if node 'roman' has just 'us' as child
+// the result will be a single 'romanus' node.
+// This node must be owned by the given generation.
+func (t *Trie[V]) merge_child(gen uint64) {
+	c := t.Children[0]
+
+	// allocating a new slice: chars may
+	// share the backing array with a sibling
+	// created during a previous split
+	chars := make([]rune, 0, len(t.chars)+len(c.chars))
+	chars = append(chars, t.chars...)
+	chars = append(chars, c.chars...)
+
+	t.chars = chars
+	t.IsWord = c.IsWord
+	t.data = c.data
+	t.shares = c.shares
+	t.weight = c.weight
+	t.maxWeight = c.maxWeight
+	t.words = c.words
+	t.nodes = c.nodes
+	t.Children = c.Children
+	if c.gen != gen {
+		// the children slice of a shared
+		// node must not be modified
+		t.Children = make([]*Trie[V], len(c.Children), len(c.Children)+1)
+		copy(t.Children, c.Children)
 	}
+}
 
-	// TODO: clarify this
-	if len(sub1) != 0 {
-		// appending sub1 contents
-		sub1_c := new(Trie)
-		sub1_c.isRoot = false
-		sub1_c.IsWord = was_word
-		sub1_c.Parent = last_node
-		sub1_c.chars = sub1
-		sub1_c.depth = last_node.depth // will increase this later
-		sub1_c.Children = last_node.Children
-		sub1_c.data = last_node.data
-		last_node.data = nil
-
-		// we need to update children depth
-		// since we have just moved this
-		// subtree one lever lower
-		sub1_c.increase_depth()
-
-		// an important thing to remember is that
-		// sub1_c inherits all the children from
-		// last_node which has now been split
-		last_node.Children = []*Trie{sub1_c}
+// Removes the word mark from the last node
+// of the given path and compacts the radix
+// tree around it: empty leaves are pruned and
+// non-word nodes left with a single child are
+// merged with it. All the nodes in the path,
+// from the root on, must be owned by the root.
+func unmark_word[V any](path []*Trie[V]) {
+	gen := path[0].gen
+	n := path[len(path)-1]
+	n.IsWord = false
+	n.data = nil
+	n.shares = nil
+	n.weight = 0
+
+	if len(n.Children) == 0 {
+		path = path[:len(path)-1]
+		p := path[len(path)-1]
+		p.delete_child(string(n.chars))
+		n = p
 	}
 
-	if len(sub2) != 0 {
-		// appending sub2 contents
-		sub2_c := new(Trie)
-		sub2_c.isRoot = false
-		sub2_c.IsWord = true
-		sub2_c.Parent = last_node
-		sub2_c.chars = sub2
-		sub2_c.depth = last_node.depth + 1
-		sub2_c.Children = make([]*Trie, 0, 1)
-		sub2_c.data = data
-		last_node.Children = append(last_node.Children, sub2_c)
+	if !n.isRoot && !n.IsWord && len(n.Children) == 1 {
+		n.merge_child(gen)
 	}
+	update_subtree(path)
 }
 
-// Returns true if the word is found
-// in the radix tree
-func (t *Trie) HasWord(word string) bool {
-	suffix := []rune(word)
-	cn := t
-	current_children := []*Trie{}
+// Returns the node whose full path
+// matches exactly the given word or nil
+// if no such node exists
+func (t *Trie[V]) find_node(word []rune) *Trie[V] {
+	if len(word) == 0 {
+		return nil
+	}
 
-	for cn != nil {
-		if !cn.isRoot {
-			if suffix[0] != cn.chars[0] {
-				goto next
-			}
-			last := same_until(suffix, cn.chars)
-			if last == len(cn.chars)-1 && len(suffix) == len(cn.chars) {
-				return cn.IsWord // exact node match
-			} else {
-				suffix = suffix[last+1:]
-			}
-			// node contains the whole
-			// suffix string: this means
-			// we haven't found an exact
-			// match
-			if len(suffix) == 0 {
-				return false
-			}
+	cn := t
+	for len(word) > 0 {
+		cn = cn.find_child(word[0])
+
+		// the node is not entirely
+		// shared with the word: no
+		// node can match below it
+		if cn == nil || same_until(word, cn.chars) != len(cn.chars)-1 {
+			return nil
 		}
+		word = word[len(cn.chars):]
+	}
 
-		current_children = cn.Children
-	next:
-		if len(current_children) != 0 {
-			cn = current_children[0]
-			current_children = current_children[1:]
-		} else {
-			cn = nil
+	return cn
+}
+
+// Returns the nodes from the root to
+// the node matching exactly the given word,
+// which must be present in the radix tree.
+// Every node in the path is made owned by
+// the radix tree so that it can be modified.
+func (t *Trie[V]) own_path(word []rune) []*Trie[V] {
+	path := []*Trie[V]{t}
+	cn := t
+
+	for len(word) > 0 {
+		i := cn.child_index(word[0])
+		if i < 0 || same_until(word, cn.Children[i].chars) != len(cn.Children[i].chars)-1 {
+			return nil
 		}
+
+		cn = cn.own_child(i, t.gen)
+		path = append(path, cn)
+		word = word[len(cn.chars):]
 	}
 
-	return false
+	return path
 }
 
-// Returns an array of objects that are associated
-// with the words closest to the specified word param
-func (t *Trie) ClosestWords(word string) []interface{} {
-	suffix := []rune(word)
+// Inserts the given suffix in the trie replacing
+// its values, and their shares of its weight, with
+// the ones returned by update, which is given the
+// current ones of the suffix, if any.
+// Returns the nodes from the root to the node marked
+// as word for the suffix.
+// Nodes shared with a snapshot are never modified:
+// each one of them in the path is replaced with
+// a copy owned by the radix tree.
+func (t *Trie[V]) append_radix(suffix []rune, update func(values []V, shares []float64) ([]V, []float64)) (path []*Trie[V]) {
+	gen := t.gen
+	path = []*Trie[V]{t}
 	cn := t
-	current_children := []*Trie{}
-	var last_prefix_node *Trie = nil
 
-	prefix := []rune{}
+	for {
+		i := cn.child_index(suffix[0])
+
+		// No node found matching
+		// part of the suffix we want
+		// to append. A new one will
+		// be created
+		if i < 0 {
+			n := cn.add_child(suffix, gen)
+			n.IsWord = true
+			n.data, n.shares = update(nil, nil)
+			path = append(path, n)
+			break
+		}
 
-	for cn != nil {
-		if !cn.isRoot {
-			if suffix[0] != cn.chars[0] {
-				goto next
-			}
-			last := same_until(suffix, cn.chars)
-			// if the given suffix is equal
-			// to the node we have an exact
-			// match and therefore we return
-			// the corresponding data
-			if last == len(cn.chars)-1 && len(suffix) == len(cn.chars) {
-				if cn.IsWord {
-					return []interface{}{cn.data}
-				}
-			}
+		n := cn.own_child(i, gen)
+		path = append(path, n)
 
-			// if the given suffix
-			// is still not an empty
-			// string this means we have
-			// found a prefix for the given
-			// word
-			if last > -1 {
-				last_prefix_node = cn
-				suffix = suffix[last+1:]
-			}
-			if len(suffix) == 0 {
-				break
-			} else {
-				prefix = append(prefix, cn.chars[:last+1]...)
-			}
+		// how many characters does this node
+		// share with this suffix?
+		r := same_until(suffix, n.chars)
+		suffix = suffix[r+1:]
+
+		// there is a partial match:
+		// we need to split the matching node
+		// content so that we can add our suffix
+		if r < len(n.chars)-1 {
+			n.split(r+1, gen)
 		}
 
-		current_children = cn.Children
-	next:
-		if len(current_children) != 0 {
-			cn = current_children[0]
-			current_children = current_children[1:]
-		} else {
-			cn = nil
+		// the suffix ends exactly
+		// at this node which therefore
+		// holds the given values
+		if len(suffix) == 0 {
+			var values []V
+			var shares []float64
+			if n.IsWord {
+				values, shares = n.data, n.shares
+			}
+			n.IsWord = true
+			n.data, n.shares = update(values, shares)
+			break
 		}
+		cn = n
 	}
 
-	if last_prefix_node != nil {
-		return last_prefix_node.Words()
+	// both the split and the new
+	// word may have changed the max
+	// weight along the path
+	update_subtree(path)
+	return
+}
+
+// Returns true if the word is found
+// in the radix tree
+func (t *Trie[V]) HasWord(word string) bool {
+	n := t.find_node(t.key_runes(word))
+	return n != nil && n.IsWord
+}
+
+// Returns the value associated with
+// the given key, the most recent one if
+// the key has many. The second return value
+// reports whether the key is present
+// in the radix tree.
+func (t *Trie[V]) Get(key string) (value V, ok bool) {
+	n := t.find_node(t.key_runes(key))
+	if n == nil || !n.IsWord {
+		return
+	}
+
+	return n.value(), true
+}
+
+// Returns all the values associated with
+// the given key, from the least recent to
+// the most recent one, or nil if the key is
+// not present in the radix tree
+func (t *Trie[V]) GetAll(key string) []V {
+	n := t.find_node(t.key_runes(key))
+	if n == nil || !n.IsWord {
+		return nil
+	}
+
+	return slices.Clone(n.data)
+}
+
+// Returns the most recent value
+// of the word ending at this node
+func (t *Trie[V]) value() (v V) {
+	if len(t.data) > 0 {
+		v = t.data[len(t.data)-1]
 	}
 
-	return []interface{}{}
+	return
 }
 
-// Returns a list with all the
-// words present in the radix tree
-func (t *Trie) Words() (words []interface{}) {
+// Returns the given values with the given
+// one added as the most recent, together with
+// the weights they added to their word, share
+// being the one added by value. A value equal
+// to one of the given ones replaces it, adding
+// up their shares, and the least recent values
+// are dropped if they exceed the configured
+// maximum. The given slices are never modified.
+func (t *Trie[V]) add_value(values []V, shares []float64, value V, share float64) ([]V, []float64) {
+	if len(shares) != len(values) {
+		shares = make([]float64, len(values))
+	}
+	if i := index_value(values, value); i >= 0 {
+		share += shares[i]
+		values = slices.Delete(slices.Clone(values), i, i+1)
+		shares = slices.Delete(slices.Clone(shares), i, i+1)
+	}
+	values = append(slices.Clip(values), value)
+	shares = append(slices.Clip(shares), share)
+
+	if limit := t.config().max_values; limit > 0 && len(values) > limit {
+		values = values[len(values)-limit:]
+		shares = shares[len(shares)-limit:]
+	}
+
+	return values, shares
+}
+
+// Returns the node holding the word with
+// the given key, or nil if the key is not
+// present in the radix tree.
+// The returned node may be shared with
+// snapshots of the radix tree: it must not
+// be modified.
+func (t *Trie[V]) Node(key string) *Trie[V] {
+	n := t.find_node(t.key_runes(key))
+	if n == nil || !n.IsWord {
+		return nil
+	}
+
+	return n
+}
+
+// Returns an array of objects that are associated
+// with the words closest to the specified word param
+func (t *Trie[V]) ClosestWords(word string) []V {
+	node, _, exact := t.closest_node(t.key_runes(word))
+	if exact {
+		return slices.Clone(node.data)
+	}
+	if node != nil {
+		return node.Words()
+	}
+
+	return []V{}
+}
+
+// Returns the node ClosestWords collects
+// the words from along with its whole key.
+// When exact is true the node is a word
+// matching exactly the given suffix.
+func (t *Trie[V]) closest_node(suffix []rune) (last_prefix_node *Trie[V], key []rune, exact bool) {
+	cn := t
+	path := []rune{}
+
+	for len(suffix) > 0 {
+		cn = cn.find_child(suffix[0])
+		if cn == nil {
+			break
+		}
+		path = append(path, cn.chars...)
+
+		last := same_until(suffix, cn.chars)
+		// if the given suffix is equal
+		// to the node we have an exact
+		// match and therefore we return
+		// the corresponding data
+		if last == len(cn.chars)-1 && len(suffix) == len(cn.chars) {
+			if cn.IsWord {
+				return cn, path, true
+			}
+		}
+
+		// if the given suffix
+		// is still not an empty
+		// string this means we have
+		// found a prefix for the given
+		// word
+		last_prefix_node = cn
+		key = path
+		suffix = suffix[last+1:]
+	}
+
+	return
+}
+
+// Returns a list with all the values of
+// the words present in the radix tree
+func (t *Trie[V]) Words() (words []V) {
 	// DFS-based implementation for returning
 	// all the words in the trie
-	stack := NewStack()
+	stack := NewStack[V]()
 
-	words = make([]interface{}, 0)
+	words = make([]V, 0)
 
 	stack.Push(t)
 	for stack.Size() > 0 {
-		node := TriePtr(stack.Pop())
+		node := TriePtr[V](stack.Pop())
 
 		if !node.isRoot {
 			if node.IsWord {
-				words = append(words, node.data)
+				words = append(words, node.data...)
 			}
 		}
 
@@ -419,53 +762,64 @@ func (t *Trie) Words() (words []interface{}) {
 // allows for a O(1) push for all the node children at once
 // keeping the whole traversal MAX(O(N)) where N is the
 // number of nodes.
-func (t *Trie) EachPrefix(callback PrefixIteratorCallback) {
-	stack := NewStack()
+func (t *Trie[V]) EachPrefix(callback PrefixIteratorCallback) {
+	stack := NewStack[V]()
 	prefix := []rune{}
 
 	skipsubtree := false
 	halt := false
-	added_lengths := stackgo.NewStack()
-	last_depth := t.depth
+	added_lengths := []int{}
 
-	stack.Push(t)
+	// for each level of the current branch
+	// the number of nodes still to be visited:
+	// the depth of the next node is the
+	// number of levels
+	pending := []int{len(t.Children)}
+
+	stack.Push(t.Children...)
 	for stack.Size() != 0 {
-		node := TriePtr(stack.Pop())
-		if !node.isRoot {
-			// if we are now going up
-			// in the radix (e.g. we have
-			// finished with the current branch)
-			// then we adjust the current prefix
-			if last_depth >= node.depth {
-				var length = 0
-				for i := 0; i < (last_depth-node.depth)+1; i++ {
-					length += added_lengths.Pop().(int)
-				}
-				prefix = prefix[:len(prefix)-length]
-			}
-			last_depth = node.depth
-			shared_length := len(prefix)
-			prefix = append(prefix, node.chars...)
-			added_lengths.Push(len(node.chars))
-
-			// building the info
-			// data to pass to the callback
-			info := PrefixInfo{
-				string(prefix),
-				node.IsWord,
-				node.depth,
-				shared_length,
-			}
+		node := TriePtr[V](stack.Pop())
+		depth := len(pending)
+		pending[depth-1]--
+
+		shared_length := len(prefix)
+		prefix = append(prefix, node.chars...)
+		added_lengths = append(added_lengths, len(node.chars))
+
+		// building the info
+		// data to pass to the callback
+		info := PrefixInfo{
+			string(prefix),
+			node.IsWord,
+			depth,
+			shared_length,
+			node.weight,
+			node.words,
+			node.nodes,
+			node.maxWeight,
+		}
 
-			skipsubtree, halt = callback(info)
-			if halt {
-				return
-			}
-			if skipsubtree {
-				continue
-			}
+		skipsubtree, halt = callback(info)
+		if halt {
+			return
+		}
+		if !skipsubtree && len(node.Children) != 0 {
+			pending = append(pending, len(node.Children))
+			stack.Push(node.Children...)
+			continue
 		}
 
-		stack.Push(node.Children...)
+		// if we are now going up
+		// in the radix (e.g. we have
+		// finished with the current branch)
+		// then we adjust the current prefix
+		length := added_lengths[len(added_lengths)-1]
+		added_lengths = added_lengths[:len(added_lengths)-1]
+		for len(pending) > 1 && pending[len(pending)-1] == 0 {
+			pending = pending[:len(pending)-1]
+			length += added_lengths[len(added_lengths)-1]
+			added_lengths = added_lengths[:len(added_lengths)-1]
+		}
+		prefix = prefix[:len(prefix)-length]
 	}
 }
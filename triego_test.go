@@ -4,7 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math/rand"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -24,7 +27,7 @@ var word_tests1 = []word_test1{
 }
 
 func Test_trieFindsWords(t *testing.T) {
-	rootTrie := NewTrie()
+	rootTrie := NewTrie[string]()
 
 	for _, n := range word_tests1 {
 		if n.e == true {
@@ -41,7 +44,7 @@ func Test_trieFindsWords(t *testing.T) {
  * A utility function to make sure
  * node append works properly for our trie
  */
-func count_nodes(trie *Trie, i *int) {
+func count_nodes(trie *Trie[string], i *int) {
 	if len(trie.Children) == 0 {
 		*i = *i + 1
 		return
@@ -79,7 +82,7 @@ var node_tests = []node_test{
 
 func Test_trieNodeCount(t *testing.T) {
 	for _, v := range node_tests {
-		root_trie := NewTrie()
+		root_trie := NewTrie[string]()
 		// appending nodes
 		for _, w := range v.words {
 			root_trie.AppendWord(w)
@@ -115,12 +118,12 @@ var prefixes_tests = []prefixes_test{
 
 func Test_trieClosestWords(t *testing.T) {
 	for _, v := range prefixes_tests {
-		trie := NewTrie()
+		trie := NewTrie[string]()
 		for _, w := range v.words {
 			trie.AppendWord(w)
 		}
 
-		prefixes := trie.ClosestWords(v.query) // []interface{} which are actually strings
+		prefixes := trie.ClosestWords(v.query) // []string holding the appended phrases
 		if len(prefixes) != len(v.expected_prefixes) {
 			printTrie(trie)
 			t.Errorf("Unexpected: expected prefixes length: %d, got: %d", len(v.expected_prefixes), len(prefixes))
@@ -129,7 +132,7 @@ func Test_trieClosestWords(t *testing.T) {
 		for i := 0; i < len(v.expected_prefixes); i++ {
 			found := false
 			for j := 0; j < len(prefixes); j++ {
-				if v.expected_prefixes[i] == prefixes[j].(string) {
+				if v.expected_prefixes[i] == prefixes[j] {
 					found = true
 					break
 				}
@@ -140,7 +143,7 @@ func Test_trieClosestWords(t *testing.T) {
 		}
 		if t.Failed() {
 			for _, p := range prefixes {
-				t.Log(string(p.([]rune)))
+				t.Log(p)
 			}
 			printTrie(trie)
 		}
@@ -175,7 +178,7 @@ func Benchmark_nodesAllocation(b *testing.B) {
 
 	b.Logf("Inserting %d words in the trie", b.N)
 
-	rootTrie := NewTrie()
+	rootTrie := NewTrie[string]()
 
 	b.ResetTimer()
 	b.StartTimer()
@@ -206,7 +209,7 @@ func Benchmark_wordFind(b *testing.B) {
 		}
 		words = append(words, line[:len(line)-1])
 	}
-	rootTrie := NewTrie()
+	rootTrie := NewTrie[string]()
 
 	for i := 0; i < b.N; i++ {
 		rootTrie.AppendWord(words[i%len(words)])
@@ -226,25 +229,35 @@ func Benchmark_wordFind(b *testing.B) {
 /*
  * A few helper functions
  */
-func printTrie(trie *Trie) {
-	q := new_queue()
-	last_depth := trie.depth
 
-	q.enqueue(trie)
-	for !q.is_empty() {
-		n := q.dequeue()
-		if n.isRoot {
-			fmt.Print("/")
-		} else {
-			if n.depth > last_depth {
-				fmt.Println()
-			}
-			fmt.Printf("(%s,%v,%d)\t", string(n.chars), n.IsWord, n.depth)
+// Returns the depth of the node the given
+// word ends at, counting the nodes from the
+// root, and false if there is no such node
+func node_depth[V any](trie *Trie[V], word []rune) (depth int, ok bool) {
+	cn := trie
+	for len(word) > 0 {
+		cn = cn.find_child(word[0])
+		if cn == nil || same_until(word, cn.chars) != len(cn.chars)-1 {
+			return 0, false
 		}
-		for _, c := range n.Children {
-			q.enqueue(c)
+		word = word[len(cn.chars):]
+		depth++
+	}
+
+	return depth, true
+}
+
+func printTrie(trie *Trie[string]) {
+	fmt.Print("/")
+	level := trie.Children
+	for depth := 1; len(level) > 0; depth++ {
+		fmt.Println()
+		var next []*Trie[string]
+		for _, n := range level {
+			fmt.Printf("(%s,%v,%d)\t", string(n.chars), n.IsWord, depth)
+			next = append(next, n.Children...)
 		}
-		last_depth = n.depth
+		level = next
 	}
 	fmt.Println()
 }
@@ -259,7 +272,7 @@ var words_tests = []words_test{
 
 func Test_Words(t *testing.T) {
 	for _, v := range words_tests {
-		trie := NewTrie()
+		trie := NewTrie[string]()
 		trie.AppendWords(v.words...)
 
 		for _, w := range v.words {
@@ -301,7 +314,7 @@ var prefix_tests = []prefix_test_t{
 
 func Test_EachPrefix(t *testing.T) {
 	for _, tc := range prefix_tests {
-		radix := NewTrie()
+		radix := NewTrie[string]()
 		for _, w := range tc.input_prefixes {
 			radix.AppendWord(w)
 		}
@@ -329,3 +342,398 @@ func Test_EachPrefix(t *testing.T) {
 		}
 	}
 }
+
+type remove_test struct {
+	words    []string
+	remove   string
+	removed  bool
+	expected []string // words still expected in the trie
+	nodes    int
+}
+
+var remove_tests = []remove_test{
+	{
+		[]string{"romane", "romanus", "romulus"},
+		"romanus",
+		true,
+		[]string{"romane", "romulus"},
+		4,
+	},
+	{
+		[]string{"arma", "armatura", "armento"},
+		"arma",
+		true,
+		[]string{"armatura", "armento"},
+		4,
+	},
+	{
+		[]string{"arma", "armatura", "armento"},
+		"armento",
+		true,
+		[]string{"arma", "armatura"},
+		3,
+	},
+	{
+		[]string{"dopo domani", "domenica"},
+		"dopo domani",
+		true,
+		[]string{"domenica"},
+		2,
+	},
+	{
+		[]string{"cat", "cattelan"},
+		"ca",
+		false,
+		[]string{"cat", "cattelan"},
+		3,
+	},
+}
+
+func Test_RemoveWord(t *testing.T) {
+	for _, v := range remove_tests {
+		trie := NewTrie[string]()
+		trie.AppendWords(v.words...)
+
+		if removed := trie.RemoveWord(v.remove); removed != v.removed {
+			t.Errorf("Unexpected RemoveWord('%s') result: got %v, expected %v", v.remove, removed, v.removed)
+		}
+		if v.removed && trie.HasWord(v.remove) {
+			t.Errorf("Unexpected: word '%s' still found after removal", v.remove)
+		}
+		for _, w := range v.expected {
+			if !trie.HasWord(w) {
+				t.Errorf("Unexpected: couldn't find word '%s' after removing '%s'", w, v.remove)
+			}
+		}
+
+		var count int = 0
+		count_nodes(trie, &count)
+		if count != v.nodes {
+			t.Errorf("Unexpected node count after removing '%s': got %d, expected %d", v.remove, count, v.nodes)
+		}
+		if t.Failed() {
+			printTrie(trie)
+		}
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	trie := NewTrie[int]()
+	trie.Insert("new york", 1)
+	trie.Insert("new", 2)
+	trie.Insert("newark", 3)
+
+	snapshot := trie.Snapshot()
+	if !trie.Delete("new york") {
+		t.Errorf("Unexpected Delete('new york') result")
+	}
+	if trie.Delete("new york") || trie.Delete("york") || trie.Delete("ne") {
+		t.Errorf("Unexpected Delete result for a missing key")
+	}
+	if _, ok := trie.Get("new york"); ok {
+		t.Errorf("Unexpected: key 'new york' still found after Delete")
+	}
+	if v, _ := snapshot.Get("new york"); v != 1 {
+		t.Errorf("Unexpected snapshot value after Delete: %d", v)
+	}
+	if key, ok := check_compact(trie); !ok || trie.Len() != 2 {
+		t.Errorf("Unexpected node '%s' after Delete", key)
+	}
+}
+
+func Test_MultipleValues(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("new york", "york minster", "new york")
+
+	expected := []string{"york minster", "new york"}
+	if words := trie.ClosestWords("york"); !slices.Equal(words, expected) {
+		t.Errorf("Unexpected ClosestWords('york') result: got %v, expected %v", words, expected)
+	}
+	if value, _ := trie.Get("york"); value != "new york" {
+		t.Errorf("Unexpected Get('york') result: %s", value)
+	}
+	if words := trie.Words(); len(words) != 4 {
+		t.Errorf("Unexpected Words() result: %v", words)
+	}
+
+	snapshot := trie.Snapshot()
+	if !trie.RemoveWord("new york") {
+		t.Errorf("Unexpected RemoveWord('new york') result")
+	}
+	if trie.HasWord("new") {
+		t.Errorf("Unexpected: word 'new' still found after removal")
+	}
+	if values := trie.GetAll("york"); !slices.Equal(values, []string{"york minster"}) {
+		t.Errorf("Unexpected GetAll('york') result after removal: %v", values)
+	}
+	if values := snapshot.GetAll("york"); !slices.Equal(values, expected) {
+		t.Errorf("Unexpected GetAll('york') result for the snapshot: %v", values)
+	}
+
+	capped := NewTrie[string](WithMaxValues(2))
+	capped.AppendWords("york", "new york", "york minster")
+	if values := capped.GetAll("york"); !slices.Equal(values, []string{"new york", "york minster"}) {
+		t.Errorf("Unexpected GetAll('york') result with 2 max values: %v", values)
+	}
+
+	// Insert replaces all the values
+	trie.Insert("york", "yorkshire")
+	if values := trie.GetAll("york"); !slices.Equal(values, []string{"yorkshire"}) {
+		t.Errorf("Unexpected GetAll('york') result after Insert: %v", values)
+	}
+}
+
+type record struct {
+	id    int
+	score float64
+}
+
+type insert_test struct {
+	key      string
+	value    record
+	expected bool
+}
+
+var insert_tests = []insert_test{
+	{"new york", record{1, 0.5}, true},
+	{"new", record{2, 0.1}, true},
+	{"newark", record{3, 0.2}, true},
+	{"ne", record{}, false},
+	{"york", record{}, false},
+}
+
+func Test_InsertGet(t *testing.T) {
+	trie := NewTrie[record]()
+	for _, v := range insert_tests {
+		if v.expected {
+			trie.Insert(v.key, v.value)
+		}
+	}
+
+	for _, v := range insert_tests {
+		value, ok := trie.Get(v.key)
+		if ok != v.expected {
+			t.Errorf("Unexpected Get('%s') result: got %v, expected %v", v.key, ok, v.expected)
+		}
+		if value != v.value {
+			t.Errorf("Unexpected value for key '%s': got %v, expected %v", v.key, value, v.value)
+		}
+	}
+
+	words := trie.ClosestWords("new")
+	if len(words) != 1 || words[0].id != 2 {
+		t.Errorf("Unexpected ClosestWords('new') result: %v", words)
+	}
+}
+
+func Test_Put(t *testing.T) {
+	trie := NewTrie[interface{}]()
+	trie.Put("new york", 8336817)
+	trie.Put("york", "minster")
+
+	if trie.HasWord("new") {
+		t.Errorf("Unexpected: key 'new york' has been split")
+	}
+	if v, ok := trie.Get("new york"); !ok || v != 8336817 {
+		t.Errorf("Unexpected Get('new york') result: got (%v, %v), expected (8336817, true)", v, ok)
+	}
+	if v, ok := trie.Get("york"); !ok || v != "minster" {
+		t.Errorf("Unexpected Get('york') result: got (%v, %v), expected (minster, true)", v, ok)
+	}
+}
+
+func Test_Node(t *testing.T) {
+	trie := NewTrie[int]()
+	trie.Insert("romane", 1)
+	trie.Insert("romanus", 2)
+
+	if n := trie.Node("romanus"); n == nil || !n.IsWord || n.value() != 2 {
+		t.Errorf("Unexpected Node('romanus') result: %v", n)
+	}
+	// "roman" is a prefix shared by
+	// both keys but not a key itself
+	if n := trie.Node("roman"); n != nil {
+		t.Errorf("Unexpected Node('roman') result: %v", n)
+	}
+	if n := trie.Node("rom"); n != nil {
+		t.Errorf("Unexpected Node('rom') result: %v", n)
+	}
+	if n := trie.Node(""); n != nil {
+		t.Errorf("Unexpected Node('') result: %v", n)
+	}
+}
+
+func Test_Update(t *testing.T) {
+	trie := NewTrie[int]()
+	words := []string{"to", "be", "or", "not", "to", "be"}
+	for _, w := range words {
+		trie.Update(w, func(old int, exists bool) int {
+			if exists != (old != 0) {
+				t.Errorf("Unexpected exists for '%s': %v with %d", w, exists, old)
+			}
+			return old + 1
+		})
+	}
+
+	expected := map[string]int{"to": 2, "be": 2, "or": 1, "not": 1}
+	for k, count := range expected {
+		if v, ok := trie.Get(k); !ok || v != count {
+			t.Errorf("Unexpected Get('%s') result: got (%d, %v), expected %d", k, v, ok, count)
+		}
+	}
+
+	// the weight is preserved
+	trie.InsertWeighted("or", 1, 0.5)
+	trie.Update("or", func(old int, exists bool) int { return old * 10 })
+	if n := trie.Node("or"); n == nil || n.value() != 10 || n.weight != 0.5 {
+		t.Errorf("Unexpected node after Update: %v", n)
+	}
+
+	// snapshots are not affected
+	snapshot := trie.Snapshot()
+	trie.Update("to", func(old int, exists bool) int { return 0 })
+	if v, _ := snapshot.Get("to"); v != 2 {
+		t.Errorf("Unexpected snapshot value after Update: %d", v)
+	}
+}
+
+func Test_EachPrefixTraversal(t *testing.T) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	radix := NewTrie[string]()
+	expected := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		radix.Insert(scanner.Text(), scanner.Text())
+		expected[scanner.Text()] = true
+	}
+
+	previous := []rune{}
+	words := 0
+	radix.EachPrefix(func(info PrefixInfo) (skip_subtree, halt bool) {
+		prefix := []rune(info.Prefix)
+		if info.SharedLength > len(previous) || string(prefix[:info.SharedLength]) != string(previous[:info.SharedLength]) {
+			t.Errorf("Unexpected shared length %d between '%s' and '%s'", info.SharedLength, string(previous), info.Prefix)
+		}
+		if depth, ok := node_depth(radix, prefix); !ok || depth != info.Depth {
+			t.Errorf("Unexpected depth %d for prefix '%s'", info.Depth, info.Prefix)
+		}
+		if info.IsWord {
+			words++
+			if !expected[info.Prefix] {
+				t.Errorf("Unexpected word prefix '%s'", info.Prefix)
+			}
+		}
+		previous = prefix
+
+		// skipping every subtree
+		// under the 'S' prefix
+		return info.Prefix == "S", false
+	})
+
+	skipped := 0
+	for w := range expected {
+		if strings.HasPrefix(w, "S") {
+			skipped++
+		}
+	}
+	if words != len(expected)-skipped {
+		t.Errorf("Unexpected count of word prefixes: got %d, expected %d", words, len(expected)-skipped)
+	}
+}
+
+/*
+ * Returns a deterministic corpus of random
+ * words drawn from a wide alphabet so that
+ * nodes close to the root have a high fanout
+ */
+func random_corpus(size int) []string {
+	alphabet := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789àèéìòùçñßøåæ日本語中文한국어")
+	rnd := rand.New(rand.NewSource(42))
+	words := make([]string, size)
+	for i := range words {
+		word := make([]rune, 3+rnd.Intn(10))
+		for j := range word {
+			word[j] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		words[i] = string(word)
+	}
+
+	return words
+}
+
+func Test_childrenSorted(t *testing.T) {
+	trie := NewTrie[string]()
+	words := random_corpus(5000)
+	trie.AppendWords(words...)
+	for _, w := range words[:2500] {
+		trie.RemoveWord(w)
+	}
+
+	q := new_queue[string]()
+	q.enqueue(trie)
+	for !q.is_empty() {
+		n := q.dequeue()
+		for i, c := range n.Children {
+			if i > 0 && n.Children[i-1].chars[0] >= c.chars[0] {
+				t.Fatalf("Unexpected children order under node '%s': '%s' before '%s'", string(n.chars), string(n.Children[i-1].chars), string(c.chars))
+			}
+			q.enqueue(c)
+		}
+	}
+
+	for _, w := range words[2500:] {
+		if !trie.HasWord(w) {
+			t.Errorf("Unexpected: couldn't find word '%s'", w)
+		}
+	}
+}
+
+func benchmark_hasWord(b *testing.B, words []string) {
+	trie := NewTrie[string]()
+	trie.AppendWords(words...)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !trie.HasWord(words[i%len(words)]) {
+			b.Fatalf("Unexpected: couldn't find word '%s'", words[i%len(words)])
+		}
+	}
+}
+
+func Benchmark_hasWordCountries(b *testing.B) {
+	file, err := os.Open("testdata/countries.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer file.Close()
+
+	words := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		words = append(words, strings.Fields(scanner.Text())...)
+	}
+
+	benchmark_hasWord(b, words)
+}
+
+func Benchmark_hasWordLargeCorpus(b *testing.B) {
+	benchmark_hasWord(b, random_corpus(200000))
+}
+
+func Benchmark_childPosition(b *testing.B) {
+	trie := NewTrie[string]()
+	for r := rune(0x4e00); r < 0x4e00+512; r++ {
+		trie.AppendWord(string(r))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trie.child_position(rune(0x4e00 + i%512))
+	}
+}
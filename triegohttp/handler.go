@@ -0,0 +1,365 @@
+// Package triegohttp serves a triego radix
+// tree over HTTP with JSON endpoints:
+//
+//	GET  /complete?q=prefix&limit=10          ranked completions
+//	GET  /fuzzy?q=query&distance=1&limit=10   fuzzy matches
+//	GET  /lookup?key=key                      exact lookup
+//	POST /insert {"key", "value", "weight"}   insertion
+//	POST /delete {"key"}                      deletion
+//
+// Mount the handler under a path prefix with
+// http.StripPrefix.
+package triegohttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/typeflow/triego"
+)
+
+const (
+	k_DEFAULT_LIMIT        = 10
+	k_DEFAULT_MAX_LIMIT    = 100
+	k_DEFAULT_MAX_DISTANCE = 2
+	k_DEFAULT_MAX_QUERY    = 256
+	k_DEFAULT_MAX_BODY     = 1 << 20
+	k_DEFAULT_TIMEOUT      = 5 * time.Second
+)
+
+// Options configures a handler.
+// Zero values stand for the defaults.
+type Options struct {
+	// the number of matches returned when
+	// the request does not specify a limit,
+	// 10 by default, and the maximum one,
+	// 100 by default: higher limits are
+	// lowered to it
+	DefaultLimit int
+	MaxLimit     int
+
+	// the maximum edit distance of fuzzy
+	// searches, 2 by default
+	MaxDistance int
+
+	// the maximum length, in runes, of
+	// queries and keys, 256 by default
+	MaxQueryLength int
+
+	// the maximum size of request
+	// bodies, 1MB by default
+	MaxBodyBytes int64
+
+	// how long a request can take before
+	// being answered with 503 Service
+	// Unavailable, 5s by default.
+	// A negative timeout disables it.
+	Timeout time.Duration
+
+	// the origins allowed to call the
+	// endpoints from a browser, "*" for
+	// any origin. No CORS headers are sent
+	// if empty.
+	AllowedOrigins []string
+
+	// ReadOnly disables the
+	// insert and delete endpoints
+	ReadOnly bool
+}
+
+// A Match is a word returned by the
+// complete and fuzzy endpoints
+type Match[V any] struct {
+	Key      string  `json:"key"`
+	Value    V       `json:"value"`
+	Weight   float64 `json:"weight"`
+	Distance int     `json:"distance"`
+}
+
+// The body of the complete
+// and fuzzy responses
+type MatchesResponse[V any] struct {
+	Matches []Match[V] `json:"matches"`
+}
+
+// The body of the lookup response
+type LookupResponse[V any] struct {
+	Key    string  `json:"key"`
+	Value  V       `json:"value"`
+	Values []V     `json:"values"`
+	Weight float64 `json:"weight"`
+}
+
+// The body of the insert request. Weight
+// is preserved for an existing key if missing.
+type InsertRequest[V any] struct {
+	Key    string   `json:"key"`
+	Value  V        `json:"value"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// The body of the delete request
+type DeleteRequest struct {
+	Key string `json:"key"`
+}
+
+// The body of the delete response
+type DeleteResponse struct {
+	Deleted bool `json:"deleted"`
+}
+
+// The body of every error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type handler[V any] struct {
+	trie *triego.ConcurrentTrie[V]
+	opts Options
+}
+
+// Returns an http.Handler serving the given
+// radix tree configured with the given options
+func NewHandler[V any](trie *triego.ConcurrentTrie[V], opts Options) http.Handler {
+	opts = with_defaults(opts)
+	h := &handler[V]{trie, opts}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /complete", h.complete)
+	mux.HandleFunc("GET /fuzzy", h.fuzzy)
+	mux.HandleFunc("GET /lookup", h.lookup)
+	if !opts.ReadOnly {
+		mux.HandleFunc("POST /insert", h.insert)
+		mux.HandleFunc("POST /delete", h.delete)
+	}
+
+	var next http.Handler = mux
+	if opts.Timeout > 0 {
+		timeout := http.TimeoutHandler(mux, opts.Timeout, `{"error":"request timed out"}`)
+		next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the timeout response is written
+			// with the headers set so far
+			w.Header().Set("Content-Type", "application/json")
+			timeout.ServeHTTP(w, r)
+		})
+	}
+	if len(opts.AllowedOrigins) > 0 {
+		next = cors(next, opts.AllowedOrigins)
+	}
+
+	return next
+}
+
+func with_defaults(opts Options) Options {
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = k_DEFAULT_LIMIT
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = k_DEFAULT_MAX_LIMIT
+	}
+	opts.DefaultLimit = min(opts.DefaultLimit, opts.MaxLimit)
+	if opts.MaxDistance <= 0 {
+		opts.MaxDistance = k_DEFAULT_MAX_DISTANCE
+	}
+	if opts.MaxQueryLength <= 0 {
+		opts.MaxQueryLength = k_DEFAULT_MAX_QUERY
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = k_DEFAULT_MAX_BODY
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = k_DEFAULT_TIMEOUT
+	}
+
+	return opts
+}
+
+// Answers the preflight requests and
+// adds the CORS headers to the responses
+// for the allowed origins
+func cors(next http.Handler, origins []string) http.Handler {
+	any_origin := slices.Contains(origins, "*")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed := origin != "" && (any_origin || slices.Contains(origins, origin))
+
+		w.Header().Add("Vary", "Origin")
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.Header().Set("Access-Control-Max-Age", "600")
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *handler[V]) complete(w http.ResponseWriter, r *http.Request) {
+	q, limit, err := h.query(r, "q", true)
+	if err != nil {
+		write_error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	write_matches(w, h.trie.Complete(q, limit), limit)
+}
+
+func (h *handler[V]) fuzzy(w http.ResponseWriter, r *http.Request) {
+	q, limit, err := h.query(r, "q", false)
+	if err != nil {
+		write_error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	distance := 1
+	if d := r.URL.Query().Get("distance"); d != "" {
+		if distance, err = strconv.Atoi(d); err != nil || distance < 0 {
+			write_error(w, http.StatusBadRequest, errors.New("invalid distance"))
+			return
+		}
+	}
+	if distance > h.opts.MaxDistance {
+		write_error(w, http.StatusBadRequest, errors.New("distance too high"))
+		return
+	}
+
+	write_matches(w, h.trie.FuzzySearch(q, distance), limit)
+}
+
+func (h *handler[V]) lookup(w http.ResponseWriter, r *http.Request) {
+	key, _, err := h.query(r, "key", false)
+	if err != nil {
+		write_error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	values, weight := h.trie.Lookup(key)
+	if values == nil {
+		write_error(w, http.StatusNotFound, errors.New("key not found"))
+		return
+	}
+
+	write_json(w, http.StatusOK, LookupResponse[V]{key, values[len(values)-1], values, weight})
+}
+
+func (h *handler[V]) insert(w http.ResponseWriter, r *http.Request) {
+	var req InsertRequest[V]
+	if !h.read_body(w, r, &req) {
+		return
+	}
+
+	if req.Weight != nil {
+		h.trie.InsertWeighted(req.Key, req.Value, *req.Weight)
+	} else {
+		h.trie.Insert(req.Key, req.Value)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler[V]) delete(w http.ResponseWriter, r *http.Request) {
+	var req DeleteRequest
+	if !h.read_body(w, r, &req) {
+		return
+	}
+
+	write_json(w, http.StatusOK, DeleteResponse{h.trie.Delete(req.Key)})
+}
+
+// Returns the given query parameter along with
+// the limit of the request, checking both
+func (h *handler[V]) query(r *http.Request, name string, allow_empty bool) (q string, limit int, err error) {
+	params := r.URL.Query()
+	q = params.Get(name)
+	if err = h.check_key(q, allow_empty); err != nil {
+		return
+	}
+
+	limit = h.opts.DefaultLimit
+	if l := params.Get("limit"); l != "" {
+		if limit, err = strconv.Atoi(l); err != nil || limit <= 0 {
+			return q, 0, errors.New("invalid limit")
+		}
+	}
+
+	return q, min(limit, h.opts.MaxLimit), nil
+}
+
+func (h *handler[V]) check_key(key string, allow_empty bool) error {
+	switch {
+	case key == "" && !allow_empty:
+		return errors.New("missing key")
+	case utf8.RuneCountInString(key) > h.opts.MaxQueryLength:
+		return errors.New("key too long")
+	}
+
+	return nil
+}
+
+// Decodes the JSON body of the request into
+// req, whose key is checked. Writes the error
+// response and returns false if it fails.
+func (h *handler[V]) read_body(w http.ResponseWriter, r *http.Request, req any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBodyBytes)
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(req); err != nil {
+		var too_large *http.MaxBytesError
+		if errors.As(err, &too_large) {
+			write_error(w, http.StatusRequestEntityTooLarge, errors.New("request body too large"))
+		} else {
+			write_error(w, http.StatusBadRequest, errors.New("invalid request body"))
+		}
+		return false
+	}
+
+	var key string
+	switch req := req.(type) {
+	case *InsertRequest[V]:
+		key = req.Key
+	case *DeleteRequest:
+		key = req.Key
+	}
+	if err := h.check_key(key, false); err != nil {
+		write_error(w, http.StatusBadRequest, err)
+		return false
+	}
+
+	return true
+}
+
+func write_matches[V any](w http.ResponseWriter, matches []triego.Match[V], limit int) {
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	res := MatchesResponse[V]{make([]Match[V], len(matches))}
+	for i, m := range matches {
+		res.Matches[i] = Match[V]{m.Key, m.Value, m.Weight, m.Distance}
+	}
+	write_json(w, http.StatusOK, res)
+}
+
+func write_error(w http.ResponseWriter, status int, err error) {
+	write_json(w, status, ErrorResponse{err.Error()})
+}
+
+func write_json(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,231 @@
+package triegohttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/typeflow/triego"
+)
+
+type country struct {
+	Code string `json:"code"`
+}
+
+func new_server(t *testing.T, opts Options) (*httptest.Server, *triego.ConcurrentTrie[country]) {
+	trie := triego.NewConcurrentTrie[country](triego.WithNormalization(triego.FoldCase))
+	trie.InsertWeighted("italy", country{"IT"}, 59)
+	trie.InsertWeighted("iran", country{"IR"}, 85)
+	trie.InsertWeighted("iceland", country{"IS"}, 0.3)
+	trie.InsertWeighted("ireland", country{"IE"}, 5)
+
+	server := httptest.NewServer(NewHandler(trie, opts))
+	t.Cleanup(server.Close)
+
+	return server, trie
+}
+
+// Sends the request and decodes the
+// JSON response into body, if not nil
+func do(t *testing.T, method, url, payload string, body any) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	if body != nil {
+		if err := json.NewDecoder(res.Body).Decode(body); err != nil {
+			t.Fatalf("Unexpected response body for %s %s: %v", method, url, err)
+		}
+	}
+
+	return res
+}
+
+func keys(matches []Match[country]) string {
+	k := []string{}
+	for _, m := range matches {
+		k = append(k, m.Key)
+	}
+
+	return strings.Join(k, ",")
+}
+
+type matches_test struct {
+	path     string
+	status   int
+	expected string
+}
+
+var matches_tests = []matches_test{
+	{"/complete?q=i", http.StatusOK, "iran,italy,ireland,iceland"},
+	{"/complete?q=I&limit=2", http.StatusOK, "iran,italy"},
+	{"/complete?q=ir&limit=1000", http.StatusOK, "iran,ireland"},
+	{"/complete?q=x", http.StatusOK, ""},
+	{"/complete?q=i&limit=0", http.StatusBadRequest, ""},
+	{"/complete?q=i&limit=two", http.StatusBadRequest, ""},
+	{"/complete?q=" + strings.Repeat("i", 300), http.StatusBadRequest, ""},
+	{"/fuzzy?q=itlay", http.StatusOK, ""},
+	{"/fuzzy?q=itlay&distance=2", http.StatusOK, "italy"},
+	{"/fuzzy?q=iren", http.StatusOK, "iran"},
+	{"/fuzzy?q=iren&distance=2&limit=1", http.StatusOK, "iran"},
+	{"/fuzzy?q=iren&distance=3", http.StatusBadRequest, ""},
+	{"/fuzzy?q=", http.StatusBadRequest, ""},
+}
+
+func Test_Matches(t *testing.T) {
+	server, _ := new_server(t, Options{})
+
+	for _, v := range matches_tests {
+		var body MatchesResponse[country]
+		res := do(t, http.MethodGet, server.URL+v.path, "", &body)
+		if res.StatusCode != v.status {
+			t.Errorf("Unexpected status for %s: got %d, expected %d", v.path, res.StatusCode, v.status)
+			continue
+		}
+		if res.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected content type for %s: %s", v.path, res.Header.Get("Content-Type"))
+		}
+		if k := keys(body.Matches); v.status == http.StatusOK && k != v.expected {
+			t.Errorf("Unexpected matches for %s: got %s, expected %s", v.path, k, v.expected)
+		}
+	}
+}
+
+func Test_LookupInsertDelete(t *testing.T) {
+	server, trie := new_server(t, Options{})
+
+	var lookup LookupResponse[country]
+	if res := do(t, http.MethodGet, server.URL+"/lookup?key=Italy", "", &lookup); res.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected lookup status: %d", res.StatusCode)
+	}
+	if lookup.Value.Code != "IT" || lookup.Weight != 59 || len(lookup.Values) != 1 {
+		t.Errorf("Unexpected lookup response: %v", lookup)
+	}
+
+	var failure ErrorResponse
+	if res := do(t, http.MethodGet, server.URL+"/lookup?key=ital", "", &failure); res.StatusCode != http.StatusNotFound || failure.Error == "" {
+		t.Errorf("Unexpected lookup of a missing key: %d %v", res.StatusCode, failure)
+	}
+
+	res := do(t, http.MethodPost, server.URL+"/insert", `{"key":"India","value":{"code":"IN"},"weight":1400}`, nil)
+	if res.StatusCode != http.StatusNoContent {
+		t.Errorf("Unexpected insert status: %d", res.StatusCode)
+	}
+	if c, _ := trie.Get("india"); c.Code != "IN" {
+		t.Errorf("Unexpected value after insert: %v", c)
+	}
+	if w, _ := trie.Weight("india"); w != 1400 {
+		t.Errorf("Unexpected weight after insert: %v", w)
+	}
+
+	// inserting again without a weight
+	do(t, http.MethodPost, server.URL+"/insert", `{"key":"india","value":{"code":"IND"}}`, nil)
+	if w, _ := trie.Weight("india"); w != 1400 {
+		t.Errorf("Unexpected weight after a second insert: %v", w)
+	}
+
+	for _, payload := range []string{`{"key":""}`, `{"key":"a","unknown":1}`, `not json`} {
+		if res := do(t, http.MethodPost, server.URL+"/insert", payload, &failure); res.StatusCode != http.StatusBadRequest {
+			t.Errorf("Unexpected insert status for %s: %d", payload, res.StatusCode)
+		}
+	}
+
+	var deleted DeleteResponse
+	do(t, http.MethodPost, server.URL+"/delete", `{"key":"INDIA"}`, &deleted)
+	if !deleted.Deleted || trie.HasWord("india") {
+		t.Errorf("Unexpected delete result: %v", deleted)
+	}
+	do(t, http.MethodPost, server.URL+"/delete", `{"key":"india"}`, &deleted)
+	if deleted.Deleted {
+		t.Errorf("Unexpected delete result for a missing key")
+	}
+}
+
+func Test_Limits(t *testing.T) {
+	server, _ := new_server(t, Options{MaxBodyBytes: 64})
+	payload := `{"key":"` + strings.Repeat("a", 100) + `"}`
+	if res := do(t, http.MethodPost, server.URL+"/insert", payload, nil); res.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("Unexpected status for a large body: %d", res.StatusCode)
+	}
+
+	read_only, trie := new_server(t, Options{ReadOnly: true})
+	for _, path := range []string{"/insert", "/delete"} {
+		if res := do(t, http.MethodPost, read_only.URL+path, `{"key":"italy"}`, nil); res.StatusCode == http.StatusOK || res.StatusCode == http.StatusNoContent {
+			t.Errorf("Unexpected status for %s on a read only handler: %d", path, res.StatusCode)
+		}
+	}
+	if !trie.HasWord("italy") {
+		t.Errorf("Unexpected change through a read only handler")
+	}
+
+	if res := do(t, http.MethodGet, read_only.URL+"/complete?q=i&limit=5", "", nil); res.StatusCode != http.StatusOK {
+		t.Errorf("Unexpected status: %d", res.StatusCode)
+	}
+}
+
+func Test_CORS(t *testing.T) {
+	server, _ := new_server(t, Options{AllowedOrigins: []string{"https://typeflow.example"}})
+
+	preflight := func(origin string) *http.Response {
+		req, _ := http.NewRequest(http.MethodOptions, server.URL+"/complete", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "GET")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res
+	}
+
+	res := preflight("https://typeflow.example")
+	if res.StatusCode != http.StatusNoContent || res.Header.Get("Access-Control-Allow-Origin") != "https://typeflow.example" || res.Header.Get("Access-Control-Allow-Methods") == "" {
+		t.Errorf("Unexpected preflight response: %d %v", res.StatusCode, res.Header)
+	}
+	if res := preflight("https://other.example"); res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Unexpected CORS headers for another origin: %v", res.Header)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/complete?q=i", nil)
+	req.Header.Set("Origin", "https://typeflow.example")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Access-Control-Allow-Origin") != "https://typeflow.example" {
+		t.Errorf("Unexpected CORS response: %d %v", res.StatusCode, res.Header)
+	}
+
+	// without allowed origins no CORS
+	// header is sent at all
+	plain, _ := new_server(t, Options{})
+	if res := do(t, http.MethodGet, plain.URL+"/complete?q=i", "", nil); res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("Unexpected CORS headers: %v", res.Header)
+	}
+}
+
+func Test_Timeout(t *testing.T) {
+	trie := triego.NewConcurrentTrie[string]()
+	handler := NewHandler(trie, Options{Timeout: time.Nanosecond})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/complete?q=a", nil))
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected timeout response: %d %v", rec.Code, rec.Header())
+	}
+
+	var body ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error == "" {
+		t.Errorf("Unexpected timeout body: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,38 @@
+package triego
+
+// Appends a phrase like AppendWord but
+// increments the weight of each word part
+// by the given weight rather than by 1
+func (t *Trie[V]) AppendWeighted(phrase string, weight float64) {
+	t.append_phrase(phrase, phrase_value[V](phrase), nil, weight)
+}
+
+// Adds delta to the weight of the given key,
+// returning the new weight. The second return
+// value is false, and nothing changes, if the
+// key is not present in the radix tree.
+func (t *Trie[V]) Increment(key string, delta float64) (weight float64, ok bool) {
+	k := t.key_runes(key)
+	if n := t.find_node(k); n == nil || !n.IsWord {
+		return 0, false
+	}
+
+	path := t.own_path(k)
+	n := path[len(path)-1]
+	n.weight += delta
+	update_subtree(path)
+
+	return n.weight, true
+}
+
+// Returns the weight of the given key.
+// The second return value reports whether
+// the key is present in the radix tree.
+func (t *Trie[V]) Weight(key string) (weight float64, ok bool) {
+	n := t.find_node(t.key_runes(key))
+	if n == nil || !n.IsWord {
+		return 0, false
+	}
+
+	return n.weight, true
+}
@@ -0,0 +1,125 @@
+package triego
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_AppendWeighted(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("new york", "york minster", "york")
+	trie.AppendWeighted("yorkshire", 2.5)
+
+	expected := map[string]float64{
+		"new":       1,
+		"york":      3,
+		"minster":   1,
+		"yorkshire": 2.5,
+	}
+	for k, w := range expected {
+		if weight, ok := trie.Weight(k); !ok || weight != w {
+			t.Errorf("Unexpected Weight('%s') result: got (%v, %v), expected %v", k, weight, ok, w)
+		}
+	}
+	if _, ok := trie.Weight("yor"); ok {
+		t.Errorf("Unexpected Weight('yor') result")
+	}
+
+	// the most frequent completions first
+	matches := trie.Complete("yo", 0)
+	if len(matches) != 2 || matches[0].Key != "york" || matches[1].Key != "yorkshire" {
+		t.Errorf("Unexpected Complete('yo') result: %v", matches)
+	}
+}
+
+func Test_Increment(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("york", "yorkshire")
+
+	snapshot := trie.Snapshot()
+	if weight, ok := trie.Increment("yorkshire", 4); !ok || weight != 5 {
+		t.Errorf("Unexpected Increment('yorkshire') result: got (%v, %v), expected 5", weight, ok)
+	}
+	if _, ok := trie.Increment("yorks", 4); ok {
+		t.Errorf("Unexpected Increment('yorks') result")
+	}
+	if matches := trie.Complete("york", 1); len(matches) != 1 || matches[0].Key != "yorkshire" {
+		t.Errorf("Unexpected Complete('york') result after Increment: %v", matches)
+	}
+	if weight, _ := snapshot.Weight("yorkshire"); weight != 1 {
+		t.Errorf("Unexpected snapshot weight after Increment: %v", weight)
+	}
+
+	weights := map[string]float64{}
+	trie.EachPrefix(func(info PrefixInfo) (bool, bool) {
+		if info.IsWord {
+			weights[info.Prefix] = info.Weight
+		}
+		return false, false
+	})
+	if weights["york"] != 1 || weights["yorkshire"] != 5 {
+		t.Errorf("Unexpected EachPrefix weights: %v", weights)
+	}
+}
+
+func Test_RemoveWordWeight(t *testing.T) {
+	trie := NewTrie[string]()
+	trie.AppendWords("new york", "york minster", "york york")
+	trie.AppendWeighted("yorkshire", 2.5)
+
+	trie.RemoveWord("new york")
+	if weight, _ := trie.Weight("york"); weight != 3 {
+		t.Errorf("Unexpected Weight('york') after RemoveWord: got %v, expected 3", weight)
+	}
+
+	// every occurrence in the phrase is subtracted
+	trie.RemoveWord("york york")
+	if weight, _ := trie.Weight("york"); weight != 1 {
+		t.Errorf("Unexpected Weight('york') after RemoveWord: got %v, expected 1", weight)
+	}
+	if _, ok := trie.Weight("new"); ok {
+		t.Errorf("Unexpected Weight('new') result after RemoveWord")
+	}
+	if key, ok := check_compact(trie); !ok {
+		t.Errorf("Unexpected subtree statistics for node '%s'", key)
+	}
+
+	matches := trie.Complete("yo", 0)
+	if len(matches) != 2 || matches[0].Key != "yorkshire" || matches[1].Key != "york" {
+		t.Errorf("Unexpected Complete('yo') result after RemoveWord: %v", matches)
+	}
+
+	// a phrase appended many times
+	trie = NewTrie[string]()
+	trie.AppendWords("new york", "new york", "new jersey")
+	trie.RemoveWord("new york")
+	if weight, _ := trie.Weight("new"); weight != 1 {
+		t.Errorf("Unexpected Weight('new') after RemoveWord: got %v, expected 1", weight)
+	}
+
+	// a phrase appended with its own weight,
+	// surviving a round trip through WriteTo
+	trie = NewTrie[string]()
+	trie.AppendWeighted("new york", 5)
+	trie.AppendWord("new jersey")
+	trie.Increment("new", 0.5)
+
+	var buf bytes.Buffer
+	if _, err := trie.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewTrie[string]()
+	if _, err := loaded.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tr := range []*Trie[string]{trie, loaded} {
+		tr.RemoveWord("new york")
+		if weight, _ := tr.Weight("new"); weight != 1.5 {
+			t.Errorf("Unexpected Weight('new') after RemoveWord: got %v, expected 1.5", weight)
+		}
+		if matches := tr.Complete("", 0); len(matches) != 2 || matches[0].Key != "new" {
+			t.Errorf("Unexpected Complete('') result after RemoveWord: %v", matches)
+		}
+	}
+}